@@ -1,91 +1,346 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
 
 	"github.com/clearyalexandros/BeesInATrap/internal/game"
 )
 
 func main() {
-	// Define command-line flags
-	playerHP := flag.Int("player-hp", 100, "Starting health points for the player")
-	playerMissChance := flag.Float64("player-miss", 0.15, "Player miss chance (0.0-1.0)")
-	beesMissChance := flag.Float64("bees-miss", 0.20, "Bees miss chance (0.0-1.0)")
-	autoDelay := flag.Int("auto-delay", 500, "Auto mode delay in milliseconds")
+	os.Exit(run())
+}
+
+// run plays the game from the command line and returns the process exit code
+func run() int {
+	// Define command-line flags, reporting bad ones as an error rather than exiting with flag's own code
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	playerHP := flags.Int("player-hp", 100, "Starting health points for the player")
+	lives := flags.Int("lives", 1, "Lives the player has; each death but the last respawns them at full HP")
+	playerMissChance := flags.Float64("player-miss", 0.15, "Player miss chance (0.0-1.0)")
+	beesMissChance := flags.Float64("bees-miss", 0.20, "Bees miss chance (0.0-1.0)")
+	autoDelay := flags.Int("auto-delay", 500, "Auto mode delay in milliseconds")
+	autoMode := flags.Bool("auto", false, "Start in auto mode so the whole game plays itself without any input")
+	autoStrategy := flags.String("auto-strategy", "random", "Tactic auto mode plays: random, focus-queen, weakest or cleave-drones")
+	inputTimeout := flags.Int("input-timeout", 0, "Milliseconds to wait for a command before a turn is auto-played (0 waits forever)")
+	playerArmor := flags.Int("armor", 0, "Flat damage subtracted from every bee sting")
+	blockWindow := flags.Int("block-window", 0, "Milliseconds to type 'block' after a sting is telegraphed, negating it (0 disables)")
+	cleaves := flags.Int("cleaves", 0, "Uses of 'cleave <type>' per game")
+	stamina := flags.Int("stamina", 0, "Stamina pool: each attack costs 1 and 'rest' refills it (0 disables)")
+	weapon := flags.String("weapon", "", "Weapon damage type bees resist or are weak to: slash, blunt or pierce")
 
 	// Hive composition flags
-	queenCount := flag.Int("queens", 1, "Number of Queen bees in the hive")
-	workerCount := flag.Int("workers", 5, "Number of Worker bees in the hive")
-	droneCount := flag.Int("drones", 25, "Number of Drone bees in the hive")
+	queenCount := flags.Int("queens", 1, "Number of Queen bees in the hive")
+	workerCount := flags.Int("workers", 5, "Number of Worker bees in the hive")
+	droneCount := flags.Int("drones", 25, "Number of Drone bees in the hive")
+	hiveCode := flags.Uint64("hive-code", 0, "Packed hive code setting the Queen, Worker and Drone counts at once (overrides the count flags)")
+	firstStrike := flags.String("first-strike", "player", "Who acts first each turn: player or bees")
+	twoPlayer := flags.Bool("two-player", false, "A second player picks which bee stings each bee turn")
+	randomHive := flags.Bool("random-hive", false, "Roll a varied hive (1 Queen, 3-8 Workers, 15-35 Drones) instead of fixed counts")
+
+	// Randomness
+	seed := flags.Int64("seed", 0, "Seed for both player and bee randomness (falls back to $"+game.SeedEnvVar+", then the clock)")
+
+	// Scoring
+	timeAttack := flags.Bool("time-attack", false, "Score heavily rewards winning in few turns with HP to spare")
+
+	// Terminal bell
+	bell := flags.Bool("bell", false, "Ring the terminal bell on critical events (player death, Queen kill)")
+
+	// Hive health bar
+	progress := flags.Bool("progress", false, "Print a bar of the hive's remaining health after every turn")
+
+	// One-line turn summaries
+	summary := flags.Bool("summary", false, "Print a one-line summary after every turn (always shown in auto mode)")
+	jsonSummary := flags.Bool("json", false, "Print the end-of-game summary as a single JSON object for tooling (the game is narrated on stderr)")
+	profileTurns := flags.Bool("profile-turns", false, "After every bee turn, report the decisions' wall time against their serial-equivalent time")
+
+	// Crash recovery
+	autosavePath := flags.String("autosave", "", "Write the game state as JSON to this file after every turn")
+	logFile := flags.String("log-file", "", "Append the JSON event log to this file, rotating to <file>.1 past 1 MiB")
+
+	// Debug flag
+	debugMode := flags.Bool("debug", false, "Enable debug commands such as 'reveal'")
+
+	// End-of-game messages
+	winTemplate := flags.String("win-template", "", "Go text/template for the victory message (fields: .Turns, .PlayerHP, .BeesRemaining)")
+	loseTemplate := flags.String("lose-template", "", "Go text/template for the defeat message (fields: .Turns, .PlayerHP, .BeesRemaining)")
+
+	// Server mode
+	serveAddr := flags.String("serve", "", "Serve the game over HTTP on this address (e.g. :8080) instead of playing in the terminal")
+
+	// Shared scenarios
+	challengeCode := flags.String("challenge", "", "Play the exact scenario from a challenge code (overrides the gameplay flags)")
+
+	// Presets
+	difficulty := flags.String("difficulty", "", "Start from a named preset: easy, normal, hard or glasscannon (other flags given adjust it)")
+
+	// Saved builds
+	loadBuild := flags.String("load-build", "", "Start with a build saved by 'savebuild' in ./"+game.DefaultBuildsDir+" (other flags given adjust it)")
+
+	// Estimate the turns to win and exit
+	estimate := flags.Bool("estimate", false, "Estimate the turns needed to win by simulating games with the configuration, then exit")
+
+	// Watch a hands-free game and print a recap of its key moments
+	spectate := flags.Bool("spectate", false, "Auto-play a whole seeded game without narration, then print a recap of its key moments")
+
+	// Print the resolved configuration as JSON and exit
+	printConfig := flags.Bool("print-config", false, "Print the resolved configuration as JSON and exit")
 
 	// Help flag
-	showHelp := flag.Bool("help", false, "Show help information")
+	showHelp := flags.Bool("help", false, "Show help information")
 
-	flag.Parse()
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return game.ExitOK
+		}
+		return game.ExitError
+	}
 
 	if *showHelp {
 		fmt.Println("🐝 Bees in the Trap - Configuration Options")
 		fmt.Println("==========================================")
-		flag.PrintDefaults()
+		flags.PrintDefaults()
 		fmt.Println("\nExample usage:")
 		fmt.Println("  beesinthetrap --player-hp 150 --player-miss 0.10 --bees-miss 0.30")
 		fmt.Println("  beesinthetrap --queens 2 --workers 10 --drones 50")
 		fmt.Println("  beesinthetrap --auto-delay 1000 --help")
-		return
+		fmt.Println("  beesinthetrap --serve :8080")
+		return game.ExitOK
 	}
 
 	// Validate input ranges
 	if *playerHP <= 0 {
 		fmt.Println("Error: Player HP must be greater than 0")
-		return
+		return game.ExitError
 	}
 	if *playerMissChance < 0.0 || *playerMissChance > 1.0 {
 		fmt.Println("Error: Player miss chance must be between 0.0 and 1.0")
-		return
+		return game.ExitError
 	}
 	if *beesMissChance < 0.0 || *beesMissChance > 1.0 {
 		fmt.Println("Error: Bees miss chance must be between 0.0 and 1.0")
-		return
+		return game.ExitError
 	}
 	if *autoDelay < 0 {
 		fmt.Println("Error: Auto delay must be non-negative")
-		return
+		return game.ExitError
+	}
+	if *inputTimeout < 0 {
+		fmt.Println("Error: Input timeout must be non-negative")
+		return game.ExitError
+	}
+	if *lives < 1 {
+		fmt.Println("Error: Lives must be at least 1")
+		return game.ExitError
+	}
+	if *blockWindow < 0 {
+		fmt.Println("Error: Block window must be non-negative")
+		return game.ExitError
+	}
+	if *playerArmor < 0 {
+		fmt.Println("Error: Armor must be non-negative")
+		return game.ExitError
+	}
+	if *cleaves < 0 {
+		fmt.Println("Error: Cleaves must be non-negative")
+		return game.ExitError
+	}
+	if *stamina < 0 {
+		fmt.Println("Error: Stamina must be non-negative")
+		return game.ExitError
+	}
+	if *weapon != "" && *weapon != game.DamageSlash && *weapon != game.DamageBlunt && *weapon != game.DamagePierce {
+		fmt.Println("Error: Weapon must be slash, blunt or pierce")
+		return game.ExitError
 	}
 	if *queenCount < 0 || *workerCount < 0 || *droneCount < 0 {
 		fmt.Println("Error: Bee counts must be non-negative")
-		return
+		return game.ExitError
+	}
+
+	// Create game configuration, starting from the defaults (or the chosen preset or build)
+	// so options not given on the command line keep its values
+	if *difficulty != "" && *loadBuild != "" {
+		fmt.Println("Error: --difficulty and --load-build both pick the starting configuration, use only one")
+		return game.ExitError
+	}
+	config := game.DefaultConfig()
+	if *loadBuild != "" {
+		buildConfig, err := game.LoadBuild(game.DefaultBuildsDir, *loadBuild)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return game.ExitError
+		}
+		config = buildConfig
+	}
+	if *difficulty != "" {
+		difficultyConfig, err := game.DifficultyConfig(*difficulty)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return game.ExitError
+		}
+		config = difficultyConfig
+	}
+
+	// Only the flags actually given override the starting config
+	overrides := map[string]func(){
+		"player-hp":     func() { config.PlayerHP = *playerHP },
+		"lives":         func() { config.Lives = *lives },
+		"player-miss":   func() { config.PlayerMissChance = *playerMissChance },
+		"bees-miss":     func() { config.BeesMissChance = *beesMissChance },
+		"auto-delay":    func() { config.AutoModeDelay = *autoDelay },
+		"auto-strategy": func() { config.AutoStrategy = *autoStrategy },
+		"input-timeout": func() { config.InputTimeout = *inputTimeout },
+		"armor":         func() { config.PlayerArmor = *playerArmor },
+		"block-window":  func() { config.BlockWindow = *blockWindow },
+		"cleaves":       func() { config.CleaveCharges = *cleaves },
+		"stamina":       func() { config.MaxStamina = *stamina },
+		"weapon":        func() { config.WeaponType = *weapon },
+		"queens":        func() { config.QueenCount = *queenCount },
+		"workers":       func() { config.WorkerCount = *workerCount },
+		"drones":        func() { config.DroneCount = *droneCount },
+		"first-strike":  func() { config.FirstStrike = *firstStrike },
+		"two-player":    func() { config.TwoPlayer = *twoPlayer },
+		"random-hive":   func() { config.RandomHive = *randomHive },
+		"seed":          func() { config.PlayerSeed, config.BeeSeed = *seed, *seed },
+		"time-attack":   func() { config.TimeAttack = *timeAttack },
+		"bell":          func() { config.Bell = *bell },
+		"progress":      func() { config.ShowProgress = *progress },
+		"summary":       func() { config.TurnSummary = *summary },
+		"json":          func() { config.JSONSummary = *jsonSummary },
+		"profile-turns": func() { config.ProfileTurns = *profileTurns },
+		"autosave":      func() { config.AutosavePath = *autosavePath },
+		"log-file":      func() { config.LogFile = *logFile },
+		"debug":         func() { config.DebugMode = *debugMode },
+		"win-template":  func() { config.WinTemplate = *winTemplate },
+		"lose-template": func() { config.LoseTemplate = *loseTemplate },
+	}
+	seedSet := false
+	flags.Visit(func(f *flag.Flag) {
+		seedSet = seedSet || f.Name == "seed"
+		if override, ok := overrides[f.Name]; ok {
+			override()
+		}
+	})
+
+	// Seed precedence: the --seed flag, then the environment, then the clock
+	if !seedSet {
+		envSeed, ok, err := game.SeedFromEnv()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return game.ExitError
+		}
+		if ok {
+			config.PlayerSeed, config.BeeSeed = envSeed, envSeed
+		}
+	}
+	if *hiveCode != 0 {
+		hiveConfig, err := game.DecodeHive(*hiveCode)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return game.ExitError
+		}
+		config.QueenCount = hiveConfig.QueenCount
+		config.WorkerCount = hiveConfig.WorkerCount
+		config.DroneCount = hiveConfig.DroneCount
+	}
+	if *challengeCode != "" {
+		// The challenge fixes the scenario, while display options still come from the flags
+		challengeConfig, err := game.ParseChallengeCode(*challengeCode)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return game.ExitError
+		}
+		challengeConfig.AutoModeDelay = config.AutoModeDelay
+		challengeConfig.AutoStrategy = config.AutoStrategy
+		challengeConfig.InputTimeout = config.InputTimeout
+		challengeConfig.Bell = config.Bell
+		challengeConfig.AutosavePath = config.AutosavePath
+		challengeConfig.LogFile = config.LogFile
+		challengeConfig.ShowProgress = config.ShowProgress
+		challengeConfig.TurnSummary = config.TurnSummary
+		challengeConfig.JSONSummary = config.JSONSummary
+		challengeConfig.ProfileTurns = config.ProfileTurns
+		challengeConfig.DebugMode = config.DebugMode
+		challengeConfig.WinTemplate = config.WinTemplate
+		challengeConfig.LoseTemplate = config.LoseTemplate
+		config = challengeConfig
+	}
+	if err := config.Validate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return game.ExitError
 	}
 
-	fmt.Println("Starting Bees in the Trap...")
+	if *spectate {
+		return game.Spectate(config, os.Stdout).ExitCode()
+	}
+
+	if *estimate {
+		turns := game.EstimateTurnsToWin(config)
+		if math.IsInf(turns, 1) {
+			fmt.Printf("The player never won in %d simulated games.\n", game.EstimateGames)
+		} else {
+			fmt.Printf("Estimated turns to win: %.1f (averaged over the wins in %d simulated games)\n", turns, game.EstimateGames)
+		}
+		return game.ExitOK
+	}
 
-	// Create game configuration
-	config := game.GameConfig{
-		PlayerHP:         *playerHP,
-		PlayerMissChance: *playerMissChance,
-		BeesMissChance:   *beesMissChance,
-		AutoModeDelay:    *autoDelay,
-		QueenCount:       *queenCount,
-		WorkerCount:      *workerCount,
-		DroneCount:       *droneCount,
+	if *printConfig {
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return game.ExitError
+		}
+		fmt.Println(string(data))
+		return game.ExitOK
 	}
 
-	// Show configuration if any non-default values are used
-	if *playerHP != 100 || *playerMissChance != 0.15 || *beesMissChance != 0.20 ||
-		*autoDelay != 500 || *queenCount != 1 || *workerCount != 5 || *droneCount != 25 {
-		fmt.Printf("Custom Configuration:\n")
-		fmt.Printf("  Player HP: %d\n", *playerHP)
-		fmt.Printf("  Player Miss Chance: %.1f%%\n", *playerMissChance*100)
-		fmt.Printf("  Bees Miss Chance: %.1f%%\n", *beesMissChance*100)
-		fmt.Printf("  Auto Mode Delay: %dms\n", *autoDelay)
-		fmt.Printf("  Hive: %d Queens, %d Workers, %d Drones (%d total)\n",
-			*queenCount, *workerCount, *droneCount, *queenCount+*workerCount+*droneCount)
-		fmt.Println()
+	// With --json, stdout carries nothing but the summary, so the game is narrated on stderr
+	narration := io.Writer(os.Stdout)
+	if config.JSONSummary {
+		narration = os.Stderr
+	}
+	fmt.Fprintln(narration, "Starting Bees in the Trap...")
+
+	// Show the resolved configuration if it differs from the defaults
+	defaults := game.DefaultConfig()
+	if config.PlayerHP != defaults.PlayerHP || config.PlayerMissChance != defaults.PlayerMissChance ||
+		config.BeesMissChance != defaults.BeesMissChance || config.AutoModeDelay != defaults.AutoModeDelay ||
+		config.PlayerArmor != defaults.PlayerArmor || config.QueenCount != defaults.QueenCount ||
+		config.WorkerCount != defaults.WorkerCount || config.DroneCount != defaults.DroneCount {
+		fmt.Fprintf(narration, "Custom Configuration:\n")
+		fmt.Fprintf(narration, "  Player HP: %d\n", config.PlayerHP)
+		fmt.Fprintf(narration, "  Player Miss Chance: %.1f%%\n", config.PlayerMissChance*100)
+		fmt.Fprintf(narration, "  Bees Miss Chance: %.1f%%\n", config.BeesMissChance*100)
+		fmt.Fprintf(narration, "  Auto Mode Delay: %dms\n", config.AutoModeDelay)
+		fmt.Fprintf(narration, "  Player Armor: %d\n", config.PlayerArmor)
+		fmt.Fprintf(narration, "  Hive: %d Queens, %d Workers, %d Drones (%d total)\n",
+			config.QueenCount, config.WorkerCount, config.DroneCount, config.QueenCount+config.WorkerCount+config.DroneCount)
+		fmt.Fprintln(narration)
 	}
 
 	g := game.NewGameWithConfig(config)
+	g.AutoMode = *autoMode
+	g.Out = narration
+	g.SummaryOut = os.Stdout
+
+	if *serveAddr != "" {
+		fmt.Fprintf(narration, "Serving the game on %s (GET /status, POST /command, GET /events)\n", *serveAddr)
+		if err := http.ListenAndServe(*serveAddr, game.NewServer(g)); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		return game.ExitError
+	}
+
 	g.Start()
 
 	// Let's play!
-	g.PlayGame()
+	return g.PlayGame().ExitCode()
 }
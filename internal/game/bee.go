@@ -1,5 +1,10 @@
 package game
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Bee configuration constants
 const (
 	// Queen Bee stats
@@ -16,8 +21,34 @@ const (
 	DroneHP          = 60
 	DroneDamage      = 1
 	DroneTakesDamage = 30
+
+	// Leveling (when enabled) makes long-lived bees hit harder
+	BeeMaxLevel       = 5 // Highest level a bee can reach
+	BeeDamagePerLevel = 1 // Extra sting damage per level
 )
 
+// Weapon damage types bees can resist or be weak to
+const (
+	DamageSlash  = "slash"
+	DamageBlunt  = "blunt"
+	DamagePierce = "pierce"
+)
+
+// DefaultResistances multiply the damage each bee type takes from a weapon's damage type
+// (below 1 resists, above 1 is a weakness, missing types take normal damage)
+var DefaultResistances = map[BeeType]map[string]float64{
+	Queen:  {DamageSlash: 0.75, DamagePierce: 1.25},
+	Worker: {DamagePierce: 0.5, DamageBlunt: 1.5},
+	Drone:  {DamageBlunt: 0.5, DamageSlash: 1.5},
+}
+
+// BeeNames is the built-in list NameHive draws from when naming bees
+var BeeNames = []string{
+	"Buzzlord", "Stinger", "Honeybane", "Waxwing", "Nectaria", "Pollenheim", "Thorax",
+	"Zzyzx", "Bumble", "Combsworth", "Dronald", "Apiary", "Vespa", "Goldwing",
+	"Hexa", "Propolis", "Royal Jelly", "Skitter", "Humdrum", "Melissa",
+}
+
 type BeeType int
 
 const (
@@ -28,9 +59,9 @@ const (
 
 // BeeStats holds all the stats for a particular bee type
 type BeeStats struct {
-	HP          int
-	Damage      int
-	TakesDamage int
+	HP          int `json:"hp"`
+	Damage      int `json:"damage"`
+	TakesDamage int `json:"takes_damage"`
 }
 
 // BeeStatsTable provides O(1) lookup for bee stats by type (map access vs switch statements)
@@ -41,10 +72,14 @@ var BeeStatsTable = map[BeeType]BeeStats{
 }
 
 type Bee struct {
-	Type   BeeType
-	HP     int
-	MaxHP  int
-	Damage int
+	ID     int     `json:"id"` // Unique within a hive, assigned in creation order starting at 1
+	Type   BeeType `json:"type"`
+	HP     int     `json:"hp"`
+	MaxHP  int     `json:"max_hp"`
+	Damage int     `json:"damage"`
+	Level  int     `json:"level"`            // Levels gained by surviving turns (only grows with BeeLeveling)
+	Name   string  `json:"name,omitempty"`   // Themed name given by NameHive (empty when unnamed)
+	Healer bool    `json:"healer,omitempty"` // A Drone that heals the hive instead of stinging (see HealerDrones)
 }
 
 // NewBee creates a new bee with stats based on what type it is
@@ -58,18 +93,55 @@ func NewBee(beeType BeeType) *Bee {
 	}
 }
 
+// Clone returns an independent copy of the bee
+func (b *Bee) Clone() *Bee {
+	clone := *b
+	return &clone
+}
+
+// describe names the bee for narration: "Buzzlord the Queen" when named,
+// otherwise the article followed by the type, e.g. "a Queen bee"
+func (b *Bee) describe(article string) string {
+	if b.Name != "" {
+		return b.Name + " the " + b.Type.String()
+	}
+	return article + " " + b.Type.String() + " bee"
+}
+
 // IsAlive checks if the bee still has health left
 func (b *Bee) IsAlive() bool {
 	return b.HP > 0
 }
 
+// AttackDamage is the bee's sting damage including any levels it has gained
+func (b *Bee) AttackDamage() int {
+	return b.Damage + b.Level*BeeDamagePerLevel
+}
+
+// LevelUp raises the bee's level by one, up to BeeMaxLevel, and reports whether it changed
+func (b *Bee) LevelUp() bool {
+	if b.Level >= BeeMaxLevel {
+		return false
+	}
+	b.Level++
+	return true
+}
+
 // TakeDamage hits the bee and deals damage based on what type it is
 func (b *Bee) TakeDamage() {
 	stats := BeeStatsTable[b.Type]
-	b.HP -= stats.TakesDamage
+	b.TakeDamageAmount(stats.TakesDamage)
+}
+
+// TakeDamageAmount reduces the bee's HP by an arbitrary amount, never below zero,
+// and returns how much HP was actually removed
+func (b *Bee) TakeDamageAmount(amount int) int {
+	before := b.HP
+	b.HP -= amount
 	if b.HP < 0 {
 		b.HP = 0
 	}
+	return before - b.HP
 }
 
 // String returns the name of the bee type as a string
@@ -85,3 +157,22 @@ func (bt BeeType) String() string {
 		return "Unknown"
 	}
 }
+
+// MarshalText encodes the bee type by name so configs serialize readably
+func (bt BeeType) MarshalText() ([]byte, error) {
+	if bt != Queen && bt != Worker && bt != Drone {
+		return nil, fmt.Errorf("unknown bee type %d", int(bt))
+	}
+	return []byte(bt.String()), nil
+}
+
+// UnmarshalText decodes a bee type from its name (case-insensitive)
+func (bt *BeeType) UnmarshalText(text []byte) error {
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		if strings.EqualFold(string(text), beeType.String()) {
+			*bt = beeType
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown bee type %q", text)
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultBuildsDir is where 'savebuild' and --load-build keep named builds when no other directory is given
+const DefaultBuildsDir = "builds"
+
+// buildExt is the file extension every saved build uses
+const buildExt = ".json"
+
+// validBuildName keeps build names to safe file names, so a name can never escape the builds directory
+var validBuildName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// buildPath checks a build name and returns the file it lives in
+func buildPath(dir, name string) (string, error) {
+	if !validBuildName.MatchString(name) {
+		return "", fmt.Errorf("build name %q must use only letters, digits, '-' and '_'", name)
+	}
+	return filepath.Join(dir, name+buildExt), nil
+}
+
+// SaveBuild writes the config as JSON to a named build in dir, creating dir if needed
+// and replacing any build with the same name
+func SaveBuild(dir, name string, config GameConfig) error {
+	path, err := buildPath(dir, name)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding build: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadBuild reads a named build from dir, starting from DefaultConfig for any field the file leaves out
+func LoadBuild(dir, name string) (GameConfig, error) {
+	path, err := buildPath(dir, name)
+	if err != nil {
+		return GameConfig{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return GameConfig{}, fmt.Errorf("loading build %q: %w", name, err)
+	}
+
+	config := DefaultConfig()
+	if err := json.Unmarshal(data, &config); err != nil {
+		return GameConfig{}, fmt.Errorf("decoding build %q: %w", name, err)
+	}
+	if err := config.Validate(); err != nil {
+		return GameConfig{}, fmt.Errorf("build %q: %w", name, err)
+	}
+	return config, nil
+}
+
+// ListBuilds returns the names of the builds saved in dir in alphabetical order
+// (a missing directory just means no builds yet)
+func ListBuilds(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), buildExt)
+		if entry.IsDir() || !ok || !validBuildName.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// parseSaveBuild reads the build name from a 'savebuild <name>' command
+func parseSaveBuild(command string) (string, error) {
+	fields := strings.Fields(command)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "savebuild") {
+		return "", fmt.Errorf("usage: savebuild <name>")
+	}
+	return fields[1], nil
+}
+
+// buildsDir is where this game saves builds
+func (g *Game) buildsDir() string {
+	if g.BuildsDir == "" {
+		return DefaultBuildsDir
+	}
+	return g.BuildsDir
+}
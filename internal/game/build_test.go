@@ -0,0 +1,82 @@
+package game
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test a saved build loads back with the same configuration
+func TestSaveAndLoadBuild(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "builds")
+	config := DefaultConfig()
+	config.PlayerHP = 150
+	config.WeaponType = DamagePierce
+	config.TargetWeights = map[BeeType]float64{Queen: 2, Drone: 1}
+
+	if err := SaveBuild(dir, "pierce-tank", config); err != nil {
+		t.Fatalf("SaveBuild failed: %v", err)
+	}
+	loaded, err := LoadBuild(dir, "pierce-tank")
+	if err != nil {
+		t.Fatalf("LoadBuild failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, config) {
+		t.Errorf("Expected the loaded build to match\n got: %+v\nwant: %+v", loaded, config)
+	}
+
+	if _, err := LoadBuild(dir, "missing"); err == nil {
+		t.Error("Expected loading an unknown build to fail")
+	}
+	if err := SaveBuild(dir, "../escape", config); err == nil {
+		t.Error("Expected a build name with a path in it to be rejected")
+	}
+}
+
+// Test ListBuilds returns saved build names sorted, ignoring other files
+func TestListBuilds(t *testing.T) {
+	dir := t.TempDir()
+	if names, err := ListBuilds(filepath.Join(dir, "none")); err != nil || len(names) != 0 {
+		t.Errorf("Expected no builds in a missing directory, got %v, %v", names, err)
+	}
+
+	for _, name := range []string{"zerk", "easy", "glass_cannon"} {
+		if err := SaveBuild(dir, name, DefaultConfig()); err != nil {
+			t.Fatalf("SaveBuild %q failed: %v", name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a build"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ListBuilds(dir)
+	if err != nil {
+		t.Fatalf("ListBuilds failed: %v", err)
+	}
+	if want := []string{"easy", "glass_cannon", "zerk"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected builds %v, got %v", want, names)
+	}
+}
+
+// Test the savebuild command writes the running game's config under the name as typed
+func TestSaveBuildCommand(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerArmor = 3
+	game := NewGameWithConfig(config)
+	game.BuildsDir = t.TempDir()
+	game.In = strings.NewReader("SaveBuild Armored\nquit\n")
+	game.Out = io.Discard
+
+	game.PlayGame()
+
+	loaded, err := LoadBuild(game.BuildsDir, "Armored")
+	if err != nil {
+		t.Fatalf("Expected the build to be saved: %v", err)
+	}
+	if loaded.PlayerArmor != 3 {
+		t.Errorf("Expected the saved build to keep 3 armor, got %d", loaded.PlayerArmor)
+	}
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// challengeVersion is bumped whenever the challenge code layout changes
+const challengeVersion = 2
+
+// challenge holds the seeds and every gameplay setting that differs from DefaultConfig,
+// with short keys to keep codes compact
+type challenge struct {
+	Version    int   `json:"v"`
+	PlayerSeed int64 `json:"ps"`
+	BeeSeed    int64 `json:"bs"`
+	TimeAttack bool  `json:"ta,omitempty"`
+
+	Settings map[string]json.RawMessage `json:"cfg,omitempty"` // Gameplay fields (see gameplayFields) by JSON name
+}
+
+// ChallengeCode encodes the game's seeds and gameplay settings as a shareable code.
+// Clock-seeded games record the seeds they actually started from, so any game can be replayed.
+func (g *Game) ChallengeCode() string {
+	current, defaults := configFields(g.Config), configFields(DefaultConfig())
+	settings := make(map[string]json.RawMessage)
+	for key := range g.Config.gameplayFields() {
+		if !bytes.Equal(current[key], defaults[key]) {
+			settings[key] = current[key]
+		}
+	}
+
+	data, _ := json.Marshal(challenge{
+		Version:    challengeVersion,
+		PlayerSeed: g.playerSeed,
+		BeeSeed:    g.beeSeed,
+		TimeAttack: g.Config.TimeAttack,
+		Settings:   settings,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// configFields encodes a config as its JSON fields by name
+func configFields(config GameConfig) map[string]json.RawMessage {
+	data, _ := json.Marshal(config)
+	var fields map[string]json.RawMessage
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+// ParseChallengeCode decodes a code from ChallengeCode into a config, starting from
+// DefaultConfig for everything the code doesn't carry
+func ParseChallengeCode(s string) (GameConfig, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return GameConfig{}, fmt.Errorf("invalid challenge code: %w", err)
+	}
+
+	var c challenge
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&c); err != nil {
+		return GameConfig{}, fmt.Errorf("invalid challenge code: %w", err)
+	}
+	if c.Version != challengeVersion {
+		return GameConfig{}, fmt.Errorf("unsupported challenge code version %d", c.Version)
+	}
+
+	// Each setting replaces the default outright, so a map in the code isn't merged into the default's
+	fields := configFields(DefaultConfig())
+	gameplay := DefaultConfig().gameplayFields()
+	for key, value := range c.Settings {
+		if _, ok := gameplay[key]; !ok {
+			return GameConfig{}, fmt.Errorf("invalid challenge code: unknown setting %q", key)
+		}
+		fields[key] = value
+	}
+	data, _ = json.Marshal(fields)
+	var config GameConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return GameConfig{}, fmt.Errorf("invalid challenge code: %w", err)
+	}
+	config.PlayerSeed = c.PlayerSeed
+	config.BeeSeed = c.BeeSeed
+	config.TimeAttack = c.TimeAttack
+
+	switch {
+	case config.PlayerHP <= 0:
+		return GameConfig{}, fmt.Errorf("challenge player HP must be greater than 0, got %d", config.PlayerHP)
+	case config.PlayerMissChance < 0 || config.PlayerMissChance > 1:
+		return GameConfig{}, fmt.Errorf("challenge player miss chance must be between 0 and 1, got %g", config.PlayerMissChance)
+	case config.BeesMissChance < 0 || config.BeesMissChance > 1:
+		return GameConfig{}, fmt.Errorf("challenge bees miss chance must be between 0 and 1, got %g", config.BeesMissChance)
+	case config.QueenCount < 0 || config.WorkerCount < 0 || config.DroneCount < 0:
+		return GameConfig{}, fmt.Errorf("challenge bee counts must be non-negative")
+	case config.PlayerArmor < 0 || config.CleaveCharges < 0 || config.MaxStamina < 0:
+		return GameConfig{}, fmt.Errorf("challenge armor, cleaves and stamina must be non-negative")
+	case config.WeaponType != "" && config.WeaponType != DamageSlash && config.WeaponType != DamageBlunt && config.WeaponType != DamagePierce:
+		return GameConfig{}, fmt.Errorf("challenge weapon type %q is unknown", config.WeaponType)
+	}
+	if err := config.Validate(); err != nil {
+		return GameConfig{}, fmt.Errorf("invalid challenge: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test a challenge code decodes back to the exact config it was made from
+func TestChallengeCodeRoundTrip(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerSeed = 1234
+	config.BeeSeed = 5678
+	config.PlayerHP = 150
+	config.PlayerMissChance = 0.1
+	config.BeesMissChance = 0.35
+	config.QueenCount = 2
+	config.WorkerCount = 7
+	config.DroneCount = 40
+	config.PlayerArmor = 2
+	config.WeaponType = DamagePierce
+	config.CleaveCharges = 3
+	config.MaxStamina = 5
+	config.FirstStrike = FirstStrikeBees
+	config.TimeAttack = true
+
+	code := NewGameWithConfig(config).ChallengeCode()
+	decoded, err := ParseChallengeCode(code)
+	if err != nil {
+		t.Fatalf("Expected the code to decode, got %v", err)
+	}
+	if !reflect.DeepEqual(decoded, config) {
+		t.Errorf("Expected decoded config %+v to equal %+v", decoded, config)
+	}
+
+	// Bee stat overrides, like the glasscannon preset's, travel with the code
+	glass, err := DifficultyConfig("glasscannon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	glass.PlayerSeed = 1234
+	glass.BeeSeed = 5678
+	decoded, err = ParseChallengeCode(NewGameWithConfig(glass).ChallengeCode())
+	if err != nil {
+		t.Fatalf("Expected the glasscannon code to decode, got %v", err)
+	}
+	if !reflect.DeepEqual(decoded.BeeStats, glass.BeeStats) {
+		t.Errorf("Expected bee stats %v to survive the code, got %v", glass.BeeStats, decoded.BeeStats)
+	}
+
+	// So do lives, builds and every other gameplay option
+	config = DefaultConfig()
+	config.PlayerSeed = 1234
+	config.BeeSeed = 5678
+	config.Lives = 3
+	config.Berserker = true
+	config.AttacksPerTurn = 2
+	config.BlockWindow = 750
+	config.TwoPlayer = true
+	config.RandomHive = true
+	config.HiveRanges = map[BeeType]CountRange{Worker: {Min: 2, Max: 4}}
+	config.Resistances = map[BeeType]map[string]float64{Drone: {DamageBlunt: 0.5}}
+	decoded, err = ParseChallengeCode(NewGameWithConfig(config).ChallengeCode())
+	if err != nil {
+		t.Fatalf("Expected the code to decode, got %v", err)
+	}
+	if !reflect.DeepEqual(decoded, config) {
+		t.Errorf("Expected decoded config %+v to equal %+v", decoded, config)
+	}
+}
+
+// Test every gameplay field a challenge code carries names a real config field
+func TestGameplayFieldsAreConfigFields(t *testing.T) {
+	fields := configFields(DefaultConfig())
+	for key := range DefaultConfig().gameplayFields() {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("Gameplay field %q is not a JSON field of GameConfig", key)
+		}
+	}
+}
+
+// Test a clock-seeded game records the seeds it really used so the code still replays it
+func TestChallengeCodeResolvesClockSeeds(t *testing.T) {
+	game := NewGame()
+	decoded, err := ParseChallengeCode(game.ChallengeCode())
+	if err != nil {
+		t.Fatalf("Expected the code to decode, got %v", err)
+	}
+	if decoded.PlayerSeed == 0 || decoded.BeeSeed == 0 {
+		t.Errorf("Expected concrete seeds in the code, got %d and %d", decoded.PlayerSeed, decoded.BeeSeed)
+	}
+}
+
+// Test malformed or out-of-range codes are rejected
+func TestParseChallengeCodeInvalid(t *testing.T) {
+	zeroHP := NewGameWithConfig(DefaultConfig())
+	zeroHP.Config.PlayerHP = 0
+
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{"not base64", "!!!", "invalid challenge code"},
+		{"not json", "bm9wZQ", "invalid challenge code"},
+		{"wrong version", "eyJ2Ijo5OX0", "unsupported challenge code version"},
+		{"bad player hp", zeroHP.ChallengeCode(), "player HP"},
+		{"unknown setting", "eyJ2IjoyLCJjZmciOnsiYXV0b3NhdmVfcGF0aCI6Ii90bXAveCJ9fQ", "unknown setting"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseChallengeCode(tt.code)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package game
+
+import "time"
+
+// Clock is the source of time for think delays and auto mode pauses, so tests can swap in a virtual one
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// realClock is the default Clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// frozenClock always reads the same instant and never sleeps, for games that must replay exactly
+type frozenClock struct{ now time.Time }
+
+func (c frozenClock) Now() time.Time    { return c.now }
+func (frozenClock) Sleep(time.Duration) {}
+
+// clock returns the game's Clock, defaulting to real time
+func (g *Game) clock() Clock {
+	if g.Clock == nil {
+		return realClock{}
+	}
+	return g.Clock
+}
+
+// after closes the returned channel once d has passed on the game's Clock, like time.After
+func (g *Game) after(d time.Duration) <-chan struct{} {
+	expired := make(chan struct{})
+	clock := g.clock()
+	go func() {
+		defer close(expired)
+		clock.Sleep(d)
+	}()
+	return expired
+}
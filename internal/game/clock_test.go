@@ -0,0 +1,60 @@
+package game
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a virtual Clock: Sleep returns at once and just moves Now forward
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Test bee decisions think in virtual time with a fake clock, without really sleeping
+func TestFakeClockBeeDecision(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	clock := newFakeClock()
+	game.Clock = clock
+
+	started := clock.Now()
+	realStart := time.Now()
+	decision := game.makeBeeDecisionWithRand(game.Hive[Queen][0], rand.New(rand.NewSource(1)))
+
+	if elapsed := time.Since(realStart); elapsed > 20*time.Millisecond {
+		t.Errorf("Expected no real sleep with a fake clock, took %v", elapsed)
+	}
+	// Queens think for 50-150ms, all of it on the virtual clock
+	if decision.DecisionTime < 50*time.Millisecond || decision.DecisionTime >= 150*time.Millisecond {
+		t.Errorf("Expected a Queen's virtual think time of 50-150ms, got %v", decision.DecisionTime)
+	}
+	if advanced := clock.Now().Sub(started); advanced != decision.DecisionTime {
+		t.Errorf("Expected the clock to advance by the think time %v, advanced %v", decision.DecisionTime, advanced)
+	}
+
+	// The same seed makes the same decision in virtual time as it would in real time
+	again := game.makeBeeDecisionWithRand(game.Hive[Queen][0], rand.New(rand.NewSource(1)))
+	if again.WillHit != decision.WillHit || again.DecisionTime != decision.DecisionTime {
+		t.Errorf("Expected identical seeded decisions, got %+v and %+v", decision, again)
+	}
+}
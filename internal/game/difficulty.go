@@ -0,0 +1,57 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// difficulties are the named presets DifficultyConfig builds, each adjusting DefaultConfig
+var difficulties = map[string]func(*GameConfig){
+	"easy": func(c *GameConfig) {
+		c.PlayerHP = 200
+		c.PlayerMissChance = 0.05
+		c.BeesMissChance = 0.40
+	},
+	"normal": func(c *GameConfig) {},
+	"hard": func(c *GameConfig) {
+		c.PlayerHP = 50
+		c.PlayerMissChance = 0.25
+		c.BeesMissChance = 0.10
+	},
+	// Every bee dies to a single hit but stings three times as hard, so the race is won by whoever lands first
+	"glasscannon": func(c *GameConfig) {
+		c.BeeStats = make(map[BeeType]BeeStats, len(BeeStatsTable))
+		for beeType, stats := range BeeStatsTable {
+			c.BeeStats[beeType] = BeeStats{
+				HP:          stats.TakesDamage,
+				Damage:      stats.Damage * GlassCannonDamageMultiplier,
+				TakesDamage: stats.TakesDamage,
+			}
+		}
+	},
+}
+
+// GlassCannonDamageMultiplier is how much harder bees sting in the glasscannon preset
+const GlassCannonDamageMultiplier = 3
+
+// Difficulties lists the preset names DifficultyConfig accepts, in alphabetical order
+func Difficulties() []string {
+	names := make([]string, 0, len(difficulties))
+	for name := range difficulties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DifficultyConfig returns the configuration for a named preset (case-insensitive)
+func DifficultyConfig(name string) (GameConfig, error) {
+	apply, ok := difficulties[strings.ToLower(name)]
+	if !ok {
+		return GameConfig{}, fmt.Errorf("unknown difficulty %q (choose from %s)", name, strings.Join(Difficulties(), ", "))
+	}
+	config := DefaultConfig()
+	apply(&config)
+	return config, nil
+}
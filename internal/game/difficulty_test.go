@@ -0,0 +1,44 @@
+package game
+
+import (
+	"io"
+	"testing"
+)
+
+// Test the glasscannon preset gives one-hit bees with heavy stings
+func TestGlassCannonDifficulty(t *testing.T) {
+	config, err := DifficultyConfig("GlassCannon")
+	if err != nil {
+		t.Fatalf("DifficultyConfig failed: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected the preset to be valid: %v", err)
+	}
+	config.PlayerMissChance = 0
+	config.DisableQueenWipe = true // Count kills one at a time
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		base := BeeStatsTable[beeType]
+		bee := game.GetBeesByType(beeType)[0]
+		if bee.MaxHP > game.getDamageDealtTo(beeType) {
+			t.Errorf("Expected a %s to die to one hit, has %d HP against %d damage", beeType, bee.MaxHP, game.getDamageDealtTo(beeType))
+		}
+		if want := base.Damage * GlassCannonDamageMultiplier; bee.Damage != want {
+			t.Errorf("Expected a %s to sting for %d, got %d", beeType, want, bee.Damage)
+		}
+	}
+
+	// Any single hit lands a kill
+	game.TargetSelector = WeakestSelector{}
+	before := len(game.GetAliveBees())
+	game.PlayerAttack()
+	if after := len(game.GetAliveBees()); after != before-1 {
+		t.Errorf("Expected one hit to kill a bee, %d bees alive of %d", after, before)
+	}
+
+	if _, err := DifficultyConfig("nightmare"); err == nil {
+		t.Error("Expected an unknown difficulty to be rejected")
+	}
+}
@@ -0,0 +1,169 @@
+package game
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Event actions published on the game event feed
+const (
+	EventPlayerMiss      = "player_miss"      // The player's attack missed the hive
+	EventPlayerHit       = "player_hit"       // The player damaged a bee
+	EventBeeKilled       = "bee_killed"       // The player killed a bee
+	EventQueenWipe       = "queen_wipe"       // The Queen died and took the hive with her
+	EventBeeSting        = "bee_sting"        // A bee stung the player
+	EventBeeMiss         = "bee_miss"         // Every bee missed the player this turn
+	EventPlayerDied      = "player_died"      // The player was stung to death
+	EventPlayerRevived   = "player_revived"   // A fatal sting left the player clinging to life
+	EventPlayerRespawned = "player_respawned" // The player died but spent a spare life to fight on
+
+	EventSwarmPressure = "swarm_pressure" // The living swarm chipped the player
+	EventReinforcement = "reinforcement"  // The hive spawned a fresh Drone
+	EventEnrage        = "enrage"         // The dwindling hive made its last stand
+	EventBarbedSting   = "barbed_sting"   // A Worker died after stinging the player
+	EventBeeFled       = "bee_fled"       // A badly wounded bee fled the fight
+	EventBeeReturned   = "bee_returned"   // A fled bee rejoined the fight
+	EventBossRound     = "boss_round"     // A boss rush round began with a tougher Queen
+	EventStingBlocked  = "sting_blocked"  // The player blocked a telegraphed sting in time
+	EventBeeHealed     = "bee_healed"     // A healer Drone restored a wounded bee's HP
+)
+
+// eventBufferSize is how many events a slow subscriber can fall behind before events are dropped
+const eventBufferSize = 64
+
+// GameEvent describes one significant action in the game
+type GameEvent struct {
+	Time     time.Time `json:"time"`
+	Turn     int       `json:"turn"`
+	Action   string    `json:"action"`
+	Actor    string    `json:"actor"`
+	Target   string    `json:"target,omitempty"`
+	BeeID    int       `json:"bee_id,omitempty"` // The bee acting or acted on, when one is involved
+	Damage   int       `json:"damage,omitempty"`
+	TargetHP int       `json:"target_hp"`
+	PlayerHP int       `json:"player_hp"`
+}
+
+// Events subscribes to the game's event feed. The returned function unsubscribes and closes the channel.
+// Delivery is non-blocking: a subscriber that falls too far behind misses events rather than stalling the game.
+func (g *Game) Events() (<-chan GameEvent, func()) {
+	ch := make(chan GameEvent, eventBufferSize)
+
+	g.eventsMu.Lock()
+	if g.subscribers == nil {
+		g.subscribers = make(map[chan GameEvent]struct{})
+	}
+	g.subscribers[ch] = struct{}{}
+	g.eventsMu.Unlock()
+
+	unsubscribe := func() {
+		g.eventsMu.Lock()
+		defer g.eventsMu.Unlock()
+		if _, ok := g.subscribers[ch]; ok {
+			delete(g.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// SetLogger writes every game event to w as one JSON object per line (nil disables logging).
+// Unlike the narration on Out, the log is append-only and meant for ingestion by log tooling.
+func (g *Game) SetLogger(w io.Writer) {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+
+	g.logWriter = w
+}
+
+// GameSnapshot is a read-only copy of the game state, taken under a single lock so it's consistent
+type GameSnapshot struct {
+	Turn        int
+	PlayerHP    int
+	PlayerMaxHP int
+	Bees        []Bee // Living bees by value, in Queen, Worker, Drone order
+}
+
+// Snapshot copies the turn, player health and living bees in one consistent view
+func (g *Game) Snapshot() GameSnapshot {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	snapshot := GameSnapshot{
+		Turn:        g.Turns,
+		PlayerHP:    g.Player.HP,
+		PlayerMaxHP: g.Player.MaxHP,
+	}
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		for _, bee := range g.Hive[beeType] {
+			if bee.IsAlive() {
+				snapshot.Bees = append(snapshot.Bees, *bee.Clone())
+			}
+		}
+	}
+	return snapshot
+}
+
+// BeforeTurn registers fn to run at the start of every player turn with the turn number and a Snapshot.
+// Callbacks run in registration order without the game's state lock, so they may read the game
+// (Status, Snapshot and the like), but they run inside the turn: calling Step from one deadlocks.
+func (g *Game) BeforeTurn(fn func(turn int, snapshot GameSnapshot)) {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+
+	g.beforeTurn = append(g.beforeTurn, fn)
+}
+
+// runBeforeTurn hands every BeforeTurn callback a fresh snapshot of the turn about to be played
+func (g *Game) runBeforeTurn() {
+	g.eventsMu.Lock()
+	callbacks := append([]func(int, GameSnapshot){}, g.beforeTurn...)
+	g.eventsMu.Unlock()
+
+	for _, fn := range callbacks {
+		snapshot := g.Snapshot()
+		fn(snapshot.Turn, snapshot)
+	}
+}
+
+// emit stamps an event with the current turn and player HP and publishes it to every subscriber
+// (caller must not hold the game mutex)
+func (g *Game) emit(event GameEvent) {
+	g.mu.RLock()
+	event.Time = g.clock().Now()
+	event.Turn = g.Turns
+	event.PlayerHP = g.Player.HP
+	beesLeft := g.countAliveBeesUnsafe()
+	g.mu.RUnlock()
+
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+
+	if g.turnIndex == nil {
+		g.turnIndex = make(map[int][]int)
+	}
+	g.turnIndex[event.Turn] = append(g.turnIndex[event.Turn], len(g.transcript))
+	g.transcript = append(g.transcript, TurnRecord{
+		Turn:     event.Turn,
+		Action:   event.Action,
+		Actor:    event.Actor,
+		Target:   event.Target,
+		BeeID:    event.BeeID,
+		Damage:   event.Damage,
+		PlayerHP: event.PlayerHP,
+		BeesLeft: beesLeft,
+	})
+
+	if g.logWriter != nil {
+		json.NewEncoder(g.logWriter).Encode(event)
+	}
+
+	for ch := range g.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber is full, skip this event (non-blocking)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+// Test the structured logger writes one valid JSON event per line
+func TestSetLoggerWritesJSONLines(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 0
+	config.DroneCount = 2
+	config.PlayerMissChance = 0
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	var log bytes.Buffer
+	game.SetLogger(&log)
+
+	// Four unmissable hits clear two Drones
+	for !game.IsGameOver() {
+		if err := game.Step("hit"); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+
+	actions := make(map[string]int)
+	scanner := bufio.NewScanner(&log)
+	for scanner.Scan() {
+		var event GameEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Log line is not valid JSON: %q (%v)", scanner.Text(), err)
+		}
+		if event.Time.IsZero() || event.Turn == 0 || event.Actor == "" {
+			t.Errorf("Log entry missing timestamp, turn or actor: %+v", event)
+		}
+		actions[event.Action]++
+	}
+
+	if actions[EventPlayerHit] != 2 || actions[EventBeeKilled] != 2 {
+		t.Errorf("Expected 2 hits and 2 kills in the log, got %v", actions)
+	}
+	if actions[EventBeeSting]+actions[EventBeeMiss] == 0 {
+		t.Errorf("Expected bee actions in the log, got %v", actions)
+	}
+
+	// Disabling the logger stops output
+	game.SetLogger(nil)
+	log.Reset()
+	game.emit(GameEvent{Action: EventPlayerMiss, Actor: "player"})
+	if log.Len() != 0 {
+		t.Errorf("Expected no log output after disabling the logger, got %q", log.String())
+	}
+}
+
+// Test BeforeTurn fires once per player turn with the right turn number and a detached snapshot
+func TestBeforeTurnCallback(t *testing.T) {
+	config := DefaultConfig()
+	config.BeesMissChance = 1
+	config.PlayerMissChance = 1
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	var turns []int
+	game.BeforeTurn(func(turn int, snapshot GameSnapshot) {
+		turns = append(turns, turn)
+		if snapshot.Turn != turn || snapshot.PlayerHP != config.PlayerHP || len(snapshot.Bees) != 31 {
+			t.Errorf("Turn %d: unexpected snapshot %+v", turn, snapshot)
+		}
+		snapshot.Bees[0].HP = 0 // Must not reach the real hive
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := game.Step("hit"); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+
+	if len(turns) != 3 || turns[0] != 1 || turns[1] != 2 || turns[2] != 3 {
+		t.Errorf("Expected the callback once per turn for turns 1-3, got %v", turns)
+	}
+	if alive := len(game.GetAliveBees()); alive != 31 {
+		t.Errorf("Expected the snapshot to be a copy, %d bees alive", alive)
+	}
+}
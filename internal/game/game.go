@@ -2,50 +2,323 @@ package game
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
 	"math/rand"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
+	"unicode"
 )
 
 // Game configuration constants
 const (
 	// Default values (used when no config is provided)
-	DefaultPlayerMissChance = 0.15 // 15% chance for player to miss
-	DefaultBeesMissChance   = 0.20 // 20% chance for all bees to miss
-	DefaultAutoModeDelay    = 500  // Milliseconds to pause in auto mode
+	DefaultPlayerMissChance  = 0.15 // 15% chance for player to miss
+	DefaultBeesMissChance    = 0.20 // 20% chance for all bees to miss
+	DefaultAutoModeDelay     = 500  // Milliseconds to pause in auto mode
+	DefaultAttacksPerTurn    = 1    // Player attacks resolved by each 'hit'
+	DefaultMinBeeDamage      = 1    // Least damage a landed sting deals after armor and other modifiers
+	DefaultMaxBeeHitsPerTurn = 1    // Stings that land each bee turn, however many bees decide to hit
+	DefaultLives             = 1    // Lives the player has, so by default the first death ends the game
+	DefaultPrompt            = "Enter command (hit/finish/special/auto/status/restart/quit): "
 
 	// Default hive composition
 	DefaultQueenCount  = 1
 	DefaultWorkerCount = 5
 	DefaultDroneCount  = 25
 	DefaultTotalBees   = DefaultQueenCount + DefaultWorkerCount + DefaultDroneCount
+
+	// Hive size limits keep huge configs from exhausting memory and goroutines
+	DefaultMaxHiveSize            = 10000 // Largest hive allowed when MaxHiveSize is unset
+	DefaultMaxConcurrentDecisions = 256   // Bees deciding at once when MaxConcurrentDecisions is unset
+
+	// Default damage monitor thresholds
+	DefaultHighDamageThreshold   = 10 // Damage at or above this shows 🩸
+	DefaultMediumDamageThreshold = 5  // Damage at or above this shows ⚡
+
+	HiveHealthBarWidth = 30 // Characters in the hive health bar printed by ShowProgress
+	HiveMapWidth       = 10 // Bees per row in the hive map printed by 'map'
+
+	// Special events
+	BerserkDamageMultiplier    = 2    // Drone damage multiplier during a berserk swarm
+	BerserkerBonusPercent      = 50   // Extra damage dealt and taken by a berserker player
+	MoraleMax                  = 100  // Morale the player starts each game with
+	MoraleLossPerSting         = 10   // Morale lost whenever a sting lands
+	MoraleLossPerBeeMiss       = 2    // Morale lost to a near miss
+	MoraleGainPerKill          = 15   // Morale regained for every kill
+	MoraleMaxMissPenalty       = 0.30 // Extra miss chance at zero morale
+	DefaultRageMeterMax        = 50   // Damage taken before the special attack unlocks
+	DefaultSpecialDamage       = 20   // Damage the special attack deals to every living bee
+	SwarmPressureBeesPerDamage = 10   // Living bees needed for each point of swarm pressure damage
+	SuddenDeathMaxDoublings    = 10   // Sudden death stops escalating once bee damage is 1024x
+	GambleDamageMultiplier     = 2    // Damage multiplier when a gamble pays off
+	DefaultGambleWinChance     = 0.5  // Chance a gamble pays off when GambleWinChance is unset
+	AdaptiveAggressionSwing    = 0.15 // Most the bees' miss chance shifts either way with AdaptiveAggression
+	EnrageDamageBonus          = 3    // Extra sting damage every surviving bee gains when the hive enrages
+	EnrageMissMultiplier       = 0.5  // Enraged bees miss half as often
+	FleeBelowFraction          = 0.25 // With AllowFlee, bees below this fraction of their max HP may flee
+	DefaultBossRushRounds      = 3    // Boss rush rounds, counting the opening hive, when BossRushRounds is unset
+	BossRushScaling            = 0.5  // Extra HP and damage each boss rush round adds, as a fraction of a Queen's base stats
+	MercyRuleHPFraction        = 0.2  // The mercy rule only steps in once the player is below this fraction of their max HP
+	HealerDroneHealing         = 10   // HP a healer Drone restores to the most wounded bee each bee turn
+	SplashTargets              = 2    // Neighbouring bees of the target's type that SplashDamage reaches
+)
+
+// FledBee is a bee that fled the fight and the turn it returns on
+type FledBee struct {
+	Bee        *Bee `json:"bee"`
+	ReturnTurn int  `json:"return_turn"`
+}
+
+// CountRange is an inclusive range of bee counts for a randomized hive
+type CountRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// DefaultHiveRanges are the per-type bounds used by RandomHive when HiveRanges is empty
+var DefaultHiveRanges = map[BeeType]CountRange{
+	Queen:  {Min: 1, Max: 1},
+	Worker: {Min: 3, Max: 8},
+	Drone:  {Min: 15, Max: 35},
+}
+
+// Who acts first each turn (see GameConfig.FirstStrike)
+const (
+	FirstStrikePlayer = "player"
+	FirstStrikeBees   = "bees"
 )
 
 // GameConfig holds configurable game parameters
 type GameConfig struct {
-	PlayerHP         int
-	PlayerMissChance float64
-	BeesMissChance   float64
-	AutoModeDelay    int
-	QueenCount       int
-	WorkerCount      int
-	DroneCount       int
+	PlayerHP         int     `json:"player_hp"`
+	PlayerMissChance float64 `json:"player_miss_chance"`
+	BeesMissChance   float64 `json:"bees_miss_chance"`
+	AutoModeDelay    int     `json:"auto_mode_delay"` // Milliseconds between auto mode turns (0 plays straight through)
+	AutoStrategy     string  `json:"auto_strategy"`   // Tactic auto mode and simulations play: "random" (default), "focus-queen", "weakest" or "cleave-drones"
+	InputTimeout     int     `json:"input_timeout"`   // Milliseconds to wait for a command before a turn is auto-played (0 waits forever)
+	QueenCount       int     `json:"queen_count"`
+	WorkerCount      int     `json:"worker_count"`
+	DroneCount       int     `json:"drone_count"`
+
+	// Player options
+	PlayerArmor     int                            `json:"player_armor"`      // Flat damage subtracted from every bee sting
+	ArmorFullBlock  bool                           `json:"armor_full_block"`  // Allow armor to reduce a sting to 0 instead of the minimum of 1
+	MinBeeDamage    int                            `json:"min_bee_damage"`    // Least damage a sting deals after every modifier (shields and ArmorFullBlock still stop it)
+	SplashDamage    int                            `json:"splash_damage"`     // Damage each hit also deals to up to SplashTargets neighbours of the target's type (0 disables)
+	BlockWindow     int                            `json:"block_window"`      // Milliseconds to type 'block' after a sting is telegraphed, negating it (0 disables; not in auto mode)
+	PassiveRegen    int                            `json:"passive_regen"`     // HP restored at the start of each player turn
+	AttacksPerTurn  int                            `json:"attacks_per_turn"`  // Attacks made by each 'hit', each with its own miss roll (0 means 1)
+	Lifesteal       int                            `json:"lifesteal"`         // HP the player absorbs whenever they kill a bee
+	MaxTotalHealing int                            `json:"max_total_healing"` // Lifetime cap on HP restored by regeneration and lifesteal (0 = unlimited)
+	TargetWeights   map[BeeType]float64            `json:"target_weights"`    // Relative chance of targeting each type (empty means uniform)
+	ItemDropChance  float64                        `json:"item_drop_chance"`  // Chance a killed bee drops a shield that blocks the next sting
+	RageMeterMax    int                            `json:"rage_meter_max"`    // Damage the player must take to unlock 'special' (0 disables)
+	SpecialDamage   int                            `json:"special_damage"`    // Damage 'special' deals to every living bee
+	CleaveCharges   int                            `json:"cleave_charges"`    // Uses of 'cleave <type>' per game (0 disables)
+	MaxStamina      int                            `json:"max_stamina"`       // Stamina pool; each hit or finish costs 1 and 'rest' refills it (0 disables)
+	StaminaRegen    int                            `json:"stamina_regen"`     // Stamina recovered at the start of each player turn
+	GambleWinChance float64                        `json:"gamble_win_chance"` // Chance 'gamble' deals double damage instead of whiffing (0 uses default)
+	ReviveChance    float64                        `json:"revive_chance"`     // Chance a fatal sting leaves the player on ReviveHP instead, once per game (0 disables)
+	Lives           int                            `json:"lives"`             // Lives the player has; each death but the last respawns them at full HP against the same hive (0 means 1)
+	ReviveHP        int                            `json:"revive_hp"`         // HP the player revives with (at least 1, at most their max HP)
+	Morale          bool                           `json:"morale"`            // Stings and near misses shake the player's morale, raising their miss chance; kills restore it
+	Berserker       bool                           `json:"berserker"`         // Deal and take 50% more damage (a high-risk build)
+	WeaponType      string                         `json:"weapon_type"`       // Damage type of the player's weapon, e.g. "slash" (empty ignores resistances)
+	Resistances     map[BeeType]map[string]float64 `json:"resistances"`       // Damage multipliers per bee and damage type (nil uses DefaultResistances)
+
+	// Hive options
+	BeeStats               map[BeeType]BeeStats   `json:"bee_stats"`                // Per-type overrides of BeeStatsTable (missing types use the table)
+	BerserkChance          float64                `json:"berserk_chance"`           // Chance per bee turn that all Drones go berserk (0 disables)
+	QueenWipeSpares        []BeeType              `json:"queen_wipe_spares"`        // Bee types that survive the Queen-death wipe
+	QueenShielded          bool                   `json:"queen_shielded"`           // The Queen takes no damage from hit, finish or gamble until every Worker is dead
+	DisableQueenWipe       bool                   `json:"disable_queen_wipe"`       // Killing the Queen no longer wipes out the hive, so the other bees fight on
+	BeeLeveling            bool                   `json:"bee_leveling"`             // Bees gain a level (and damage) for every turn they survive and act
+	MaxConcurrentDecisions int                    `json:"max_concurrent_decisions"` // Upper bound on bees deciding at once in BeeTurn (0 uses default)
+	MaxHiveSize            int                    `json:"max_hive_size"`            // Largest total hive the config may ask for (0 uses default)
+	RandomHive             bool                   `json:"random_hive"`              // Roll each bee type's count from HiveRanges using the bee seed
+	HiveRanges             map[BeeType]CountRange `json:"hive_ranges"`              // Per-type bounds for RandomHive (empty uses DefaultHiveRanges, missing types use the fixed count)
+	SwarmPressure          bool                   `json:"swarm_pressure"`           // Living bees chip the player for 1 damage per 10 bees after each bee turn
+	ReinforcementChance    float64                `json:"reinforcement_chance"`     // Chance per bee turn that the hive spawns a fresh Drone (0 disables)
+	MaxReinforcements      int                    `json:"max_reinforcements"`       // Cap on Drones spawned by reinforcements per game (0 = unlimited)
+	SuddenDeathTurn        int                    `json:"sudden_death_turn"`        // From this turn on, bee damage doubles every turn (0 disables)
+	FirstStrike            string                 `json:"first_strike"`             // Who acts first each turn: "player" (default) or "bees"
+	AdaptiveAggression     bool                   `json:"adaptive_aggression"`      // Bees miss less while the player is healthy and more when they're near death
+	WorkersDieOnSting      bool                   `json:"workers_die_on_sting"`     // A Worker dies after it stings, like a real worker bee
+	TwoPlayer              bool                   `json:"two_player"`               // A second human picks which bee stings each bee turn instead of the AI (only in an interactive PlayGame)
+	EnrageBelow            float64                `json:"enrage_below"`             // Once the living fraction of the hive drops below this, the survivors enrage (0 disables)
+	NameHive               bool                   `json:"name_hive"`                // Give every bee a themed name from BeeNames, chosen with the bee seed
+	AllowFlee              bool                   `json:"allow_flee"`               // Badly wounded Workers and Drones may flee the fight after a bee turn
+	FleeChance             float64                `json:"flee_chance"`              // Chance per bee turn that each bee below FleeBelowFraction of its HP flees
+	FleeReturnTurns        int                    `json:"flee_return_turns"`        // Turns until a fled bee rejoins the fight (0 means it never returns; bees still away when the hive is cleared forfeit)
+	BossRush               bool                   `json:"boss_rush"`                // Clearing the hive summons a tougher Queen for the next round, until BossRushRounds are won
+	BossRushRounds         int                    `json:"boss_rush_rounds"`         // Rounds in a boss rush, counting the opening hive (0 uses default)
+	MaxBeeHitsPerTurn      int                    `json:"max_bee_hits_per_turn"`    // Most of the bees that decide to hit which actually sting each bee turn (0 means 1)
+	HealerDrones           int                    `json:"healer_drones"`            // The first this many Drones heal the most wounded bee instead of stinging (0 disables)
+	MercyRule              bool                   `json:"mercy_rule"`               // End the game early once a badly hurt player can no longer outpace the hive
+
+	// Randomness
+	PlayerSeed     int64 `json:"player_seed"`      // Seeds player miss rolls and targeting (0 seeds from the clock)
+	BeeSeed        int64 `json:"bee_seed"`         // Seeds bee decisions and attacker selection (0 seeds from the clock)
+	SeedFromConfig bool  `json:"seed_from_config"` // Seed any unset seed from Fingerprint instead of the clock, so identical configs replay identically
+
+	// Scoring
+	TimeAttack bool `json:"time_attack"` // Score heavily rewards finishing in few turns with HP to spare
+
+	// Display, debugging and performance
+	HighDamageThreshold   int              `json:"high_damage_threshold"`   // Damage alerts at or above this use the heavy icon (0 uses default)
+	MediumDamageThreshold int              `json:"medium_damage_threshold"` // Damage alerts at or above this use the medium icon (0 uses default)
+	Prompt                string           `json:"prompt"`                  // Interactive command prompt (empty uses DefaultPrompt)
+	DebugMode             bool             `json:"debug_mode"`              // Enables debug commands such as 'reveal'
+	DisableMonitor        bool             `json:"disable_monitor"`         // Skip the damage monitor goroutine (useful for benchmarks and simulations)
+	DisableThinkDelay     bool             `json:"disable_think_delay"`     // Bees decide instantly instead of simulating thinking time
+	InstantThink          map[BeeType]bool `json:"instant_think"`           // Bee types that decide instantly even when the think delay is on
+	WinTemplate           string           `json:"win_template"`            // text/template for the victory message, given the GameResult (empty uses default)
+	LoseTemplate          string           `json:"lose_template"`           // text/template for the defeat message, given the GameResult (empty uses default)
+	Bell                  bool             `json:"bell"`                    // Ring the terminal bell on critical events (player death, Queen kill)
+	AutosavePath          string           `json:"autosave_path"`           // Write the game state here after every turn (empty disables)
+	LogFile               string           `json:"log_file"`                // Append the JSON event log here, rotating to LogFile+".1" when full (empty disables)
+	LogFileMaxSize        int64            `json:"log_file_max_size"`       // Bytes LogFile may reach before rotating (0 uses DefaultLogFileMaxSize)
+	ShowProgress          bool             `json:"show_progress"`           // Print a bar of the hive's remaining health after every turn
+	TurnSummary           bool             `json:"turn_summary"`            // Print a one-line summary after every turn (always on in auto mode)
+	JSONSummary           bool             `json:"json_summary"`            // EndGame prints a single JSON object instead of the text summary
+	ProfileTurns          bool             `json:"profile_turns"`           // Report how much deciding concurrently saved after every AI bee turn
 }
 
 // DefaultConfig returns the default game configuration
 func DefaultConfig() GameConfig {
 	return GameConfig{
-		PlayerHP:         PlayerStartingHP,
-		PlayerMissChance: DefaultPlayerMissChance,
-		BeesMissChance:   DefaultBeesMissChance,
-		AutoModeDelay:    DefaultAutoModeDelay,
-		QueenCount:       DefaultQueenCount,
-		WorkerCount:      DefaultWorkerCount,
-		DroneCount:       DefaultDroneCount,
+		PlayerHP:          PlayerStartingHP,
+		PlayerMissChance:  DefaultPlayerMissChance,
+		BeesMissChance:    DefaultBeesMissChance,
+		AutoModeDelay:     DefaultAutoModeDelay,
+		QueenCount:        DefaultQueenCount,
+		WorkerCount:       DefaultWorkerCount,
+		DroneCount:        DefaultDroneCount,
+		AttacksPerTurn:    DefaultAttacksPerTurn,
+		MinBeeDamage:      DefaultMinBeeDamage,
+		MaxBeeHitsPerTurn: DefaultMaxBeeHitsPerTurn,
+		Lives:             DefaultLives,
+		RageMeterMax:      DefaultRageMeterMax,
+		SpecialDamage:     DefaultSpecialDamage,
+
+		HighDamageThreshold:   DefaultHighDamageThreshold,
+		MediumDamageThreshold: DefaultMediumDamageThreshold,
+	}
+}
+
+// Validate reports configuration values that would break the game
+func (c GameConfig) Validate() error {
+	maxHiveSize := c.MaxHiveSize
+	if maxHiveSize <= 0 {
+		maxHiveSize = DefaultMaxHiveSize
+	}
+	if total := c.maxHiveCount(); total > maxHiveSize {
+		return fmt.Errorf("hive of %d bees exceeds the maximum hive size of %d", total, maxHiveSize)
+	}
+
+	for beeType, stats := range c.BeeStats {
+		if stats.HP <= 0 || stats.TakesDamage <= 0 || stats.Damage < 0 {
+			return fmt.Errorf("%s stats need positive HP and damage taken and non-negative damage, got %+v", beeType, stats)
+		}
+	}
+	switch c.AutoStrategy {
+	case "", AutoStrategyRandom, AutoStrategyFocusQueen, AutoStrategyWeakest, AutoStrategyCleaveDrones:
+	default:
+		return fmt.Errorf("auto strategy must be %q, %q, %q or %q, got %q",
+			AutoStrategyRandom, AutoStrategyFocusQueen, AutoStrategyWeakest, AutoStrategyCleaveDrones, c.AutoStrategy)
+	}
+	if c.FleeChance < 0 || c.FleeChance > 1 {
+		return fmt.Errorf("flee chance must be between 0 and 1, got %g", c.FleeChance)
+	}
+	if c.FirstStrike != "" && c.FirstStrike != FirstStrikePlayer && c.FirstStrike != FirstStrikeBees {
+		return fmt.Errorf("first strike must be %q or %q, got %q", FirstStrikePlayer, FirstStrikeBees, c.FirstStrike)
+	}
+	if _, err := template.New("win").Parse(c.WinTemplate); err != nil {
+		return fmt.Errorf("invalid win template: %w", err)
+	}
+	if _, err := template.New("lose").Parse(c.LoseTemplate); err != nil {
+		return fmt.Errorf("invalid lose template: %w", err)
+	}
+	return nil
+}
+
+// commandAbbreviations maps single-letter shortcuts and aliases to the commands they stand for
+var commandAbbreviations = map[string]string{
+	"h":    "hit",
+	"f":    "finish",
+	"a":    "auto",
+	"s":    "status",
+	"q":    "quit",
+	"skip": "wait",
+	"?":    "help",
+}
+
+// interactiveCommands lists every command PlayGame understands, in the order 'help' shows them
+var interactiveCommands = []struct {
+	Name        string
+	Description string
+}{
+	{"hit (h)", "Attack a random bee"},
+	{"finish (f)", "Attack the weakest living bee to secure a kill"},
+	{"aim [id]", "Pick a living bee from a list (or by ID) and attack it"},
+	{"gamble", "Double or nothing: double damage to a random bee, or a complete whiff"},
+	{"special", "Unleash a full rage meter on every living bee"},
+	{"cleave <type>", "Hit every living bee of one type at once (limited uses)"},
+	{"rest", "Skip your attack to refill your stamina"},
+	{"wait (skip)", "Let your turn pass without attacking; the bees still act"},
+	{"auto (a)", "Let the game play itself"},
+	{"status (s)", "Show player HP, the hive and the turn count"},
+	{"odds", "Show the current hit and miss chances"},
+	{"map", "Draw the hive as a grid of bees, lowercase when wounded"},
+	{"inspect <id>", "Show one bee's HP, damage and level"},
+	{"challenge", "Print a code others can use to play this exact scenario"},
+	{"savebuild <name>", "Save the current configuration as a named build"},
+	{"!! / up", "Repeat your last attack command"},
+	{"reveal", "Show every living bee's ID and exact HP (debug mode only)"},
+	{"restart", "Start over with a fresh hive and full health"},
+	{"help (?)", "Show this list of commands"},
+	{"quit (q)", "Exit the game immediately"},
+}
+
+// PrintHelp lists the interactive commands without using a turn
+func (g *Game) PrintHelp() {
+	fmt.Fprintln(g.out(), "\n=== Commands ===")
+	for _, command := range interactiveCommands {
+		fmt.Fprintf(g.out(), "  %-16s %s\n", command.Name, command.Description)
+	}
+}
+
+// hiveRange returns the count range for a bee type, a fixed count being a range of one value
+func (c GameConfig) hiveRange(beeType BeeType, fixed int) CountRange {
+	if !c.RandomHive {
+		return CountRange{Min: fixed, Max: fixed}
 	}
+	ranges := c.HiveRanges
+	if len(ranges) == 0 {
+		ranges = DefaultHiveRanges
+	}
+	if r, ok := ranges[beeType]; ok {
+		return r
+	}
+	return CountRange{Min: fixed, Max: fixed}
+}
+
+// maxHiveCount is the largest hive the config can produce
+func (c GameConfig) maxHiveCount() int {
+	return c.hiveRange(Queen, c.QueenCount).Max +
+		c.hiveRange(Worker, c.WorkerCount).Max +
+		c.hiveRange(Drone, c.DroneCount).Max
 }
 
 // BeeDecision represents a bee's decision to attack or miss
@@ -56,15 +329,122 @@ type BeeDecision struct {
 }
 
 type Game struct {
-	Player      *Player            // Use pointer so we can modify the player
-	Hive        map[BeeType][]*Bee // Map structure enables O(1) access to bees by type
-	AliveBees   []*Bee             // Cached slice avoids O(n) scanning on each access
-	Turns       int
-	AutoMode    bool
-	rng         *rand.Rand
-	damageEvent chan int     // Channel to signal damage events for stats monitoring
-	Config      GameConfig   // Game configuration
-	mu          sync.RWMutex // Protects shared game state from concurrent access
+	Player         *Player            // Use pointer so we can modify the player
+	Hive           map[BeeType][]*Bee // Map structure enables O(1) access to bees by type
+	AliveBees      []*Bee             // Cached slice avoids O(n) scanning on each access
+	Turns          int
+	AutoMode       bool
+	LastCommand    string                 // Most recent turn-taking command, replayed by '!!'
+	HasShield      bool                   // A picked-up shield will negate the next bee sting
+	Rage           int                    // Damage taken towards the special attack, capped at RageMeterMax
+	Reinforcements int                    // Drones the hive has spawned as reinforcements this game
+	CleavesLeft    int                    // Remaining uses of 'cleave <type>' this game
+	Stamina        int                    // Remaining stamina for attacks when MaxStamina is set
+	Morale         int                    // Player morale from 0 to MoraleMax when the Morale option is set
+	Enraged        bool                   // The hive has dropped below EnrageBelow and its survivors are enraged
+	Revived        bool                   // The player has used up their one revive this game
+	LivesLeft      int                    // Lives the player has left, counting the current one
+	Fled           []FledBee              // Bees that fled the fight with AllowFlee, waiting to return
+	Round          int                    // Current boss rush round, starting at 1
+	DamageDealt    int                    // HP the player has removed from the hive this game, including Queen wipes
+	DamageTaken    int                    // HP the hive has removed from the player this game
+	TotalHealed    int                    // HP regeneration and lifesteal have restored this game, counted against MaxTotalHealing
+	nextBeeID      int                    // ID handed to the next bee added to the hive
+	nameCounts     map[string]int         // How many bees have been given each name, so repeats get a number
+	damageByBee    map[int]int            // Cumulative damage each bee (by ID) has dealt the player
+	damageByType   map[BeeType]TypeDamage // HP the player has stripped from each bee type, and the kills
+	rng            *rand.Rand             // Player-side randomness: miss rolls and targeting
+	beeRng         *rand.Rand             // Bee-side randomness: decisions and attacker selection
+	playerSeed     int64                  // Seed rng actually started from (resolved from the clock when unset)
+	beeSeed        int64                  // Seed beeRng actually started from (resolved from the clock when unset)
+	damageEvent    chan int               // Channel to signal damage events for stats monitoring
+	monitorDone    chan struct{}          // Closed once the monitor goroutine has drained and exited
+	monitorClosed  bool                   // Close has stopped the monitor; later damage isn't reported
+	monitorOut     io.Writer              // Narration writer as of the latest damage, resolved on the game's side so the monitor never reads os.Stdout itself
+	Config         GameConfig             // Game configuration
+	TargetSelector TargetSelector         // Picks which bee a landed 'hit' strikes (nil uses WeightedSelector with TargetWeights)
+	Out            io.Writer              // Where game narration is written (nil means os.Stdout)
+	SummaryOut     io.Writer              // Where the JSONSummary report is written (nil means Out), so narration can go elsewhere
+	In             io.Reader              // Where interactive commands are read from (nil means os.Stdin)
+	BuildsDir      string                 // Where 'savebuild' writes builds (empty means DefaultBuildsDir)
+	Clock          Clock                  // Time source for think delays and auto mode pauses (nil means real time)
+	input          *bufio.Scanner         // Line reader over In shared by both players in a two player game
+	lines          chan string            // Lines read from input in the background when InputTimeout is set
+	linesStop      chan struct{}          // Closed to tell the background reader to give up the input
+	linesDone      chan struct{}          // Closed once the background reader has exited
+	interactive    bool                   // PlayGame is reading commands from a person, so they can be asked for more
+	mu             sync.RWMutex           // Protects shared game state from concurrent access
+	turnMu         sync.Mutex             // Serializes whole turns driven through Step
+
+	eventsMu    sync.Mutex                  // Protects the event subscriber set and logger
+	subscribers map[chan GameEvent]struct{} // Channels receiving the event feed
+	logWriter   io.Writer                   // Structured JSON event log (nil disables)
+	beforeTurn  []func(int, GameSnapshot)   // Callbacks run at the start of every player turn
+	transcript  []TurnRecord                // Every recorded action this game
+	turnIndex   map[int][]int               // Positions in transcript of each turn's records
+}
+
+// GameStatus is a point-in-time view of the game suitable for serialization
+type GameStatus struct {
+	Turns       int  `json:"turns"`
+	PlayerHP    int  `json:"player_hp"`
+	PlayerMaxHP int  `json:"player_max_hp"`
+	Queens      int  `json:"queens"`
+	Workers     int  `json:"workers"`
+	Drones      int  `json:"drones"`
+	AliveBees   int  `json:"alive_bees"`
+	GameOver    bool `json:"game_over"`
+}
+
+// GameResult summarizes how a game stands or ended
+type GameResult struct {
+	Over          bool // Someone has won or lost
+	PlayerWon     bool // The hive is destroyed and the player survived
+	MutualDefeat  bool // The player and the last of the hive fell together
+	Turns         int
+	PlayerHP      int
+	BeesRemaining int
+}
+
+// ErrGameOver is returned by Step once the game has already been decided
+var ErrGameOver = errors.New("game is over")
+
+// ErrNoStamina is returned by Step when the player is too tired to attack and must rest
+var ErrNoStamina = errors.New("out of stamina, rest first")
+
+// ErrNoCleaves is returned by Step when 'cleave' is used with no charges left
+var ErrNoCleaves = errors.New("no cleaves left")
+
+// ErrSpecialNotReady is returned by Step when 'special' is used before the rage meter is full
+var ErrSpecialNotReady = errors.New("rage meter is not full")
+
+// ErrNoBeesOfType is returned by Step when a command targets a bee type with no living bees
+var ErrNoBeesOfType = errors.New("no living bees of that type")
+
+// CommandErrorKind classifies why Step rejected a command
+type CommandErrorKind int
+
+const (
+	CommandUnknown     CommandErrorKind = iota // Not a command the game recognizes
+	CommandInvalid                             // A known command with malformed arguments
+	CommandNoTargets                           // No living bees of the targeted type
+	CommandUnavailable                         // A resource the command needs is used up (stamina, cleaves, rage)
+)
+
+// CommandError is returned by Step when a command can't be played, so callers can tell
+// the failures apart by Kind or with errors.Is against the Err* values
+type CommandError struct {
+	Command string
+	Kind    CommandErrorKind
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
 }
 
 // NewGame sets up a fresh game with default configuration
@@ -72,301 +452,2551 @@ func NewGame() *Game {
 	return NewGameWithConfig(DefaultConfig())
 }
 
-// NewGameWithConfig sets up a fresh game with custom configuration
-func NewGameWithConfig(config GameConfig) *Game {
-	totalBees := config.QueenCount + config.WorkerCount + config.DroneCount
+// NewDeterministicGame sets up a game that replays exactly for a given config and seed, for tests in
+// packages embedding the game. Both random sources are seeded from seed (0 counts as 1, since a zero
+// seed otherwise means the clock), the config is made headless as for Simulate (no delays, timeouts,
+// blocking, second player or damage monitor), and the clock is frozen so event times never vary.
+// The same config, seed and commands always produce the same transcript and narration.
+func NewDeterministicGame(config GameConfig, seed int64) *Game {
+	if seed == 0 {
+		seed = 1
+	}
+	config = headlessConfig(config)
+	config.PlayerSeed = seed
+	config.BeeSeed = seed
+
+	game := NewGameWithConfig(config)
+	game.Clock = frozenClock{now: time.Unix(0, 0).UTC()}
+	return game
+}
 
+// NewGameChecked sets up a fresh game like NewGameWithConfig, after checking the config with
+// Validate. Prefer it for configs that come from outside the program, such as files or requests.
+func NewGameChecked(config GameConfig) (*Game, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return NewGameWithConfig(config), nil
+}
+
+// NewGameWithConfig sets up a fresh game with custom configuration. The config is trusted as
+// given; a config that fails Validate can break the game, so check it or use NewGameChecked.
+func NewGameWithConfig(config GameConfig) *Game {
+	playerSeed, beeSeed := config.PlayerSeed, config.BeeSeed
+	if config.SeedFromConfig {
+		fingerprint := config.Fingerprint()
+		if playerSeed == 0 {
+			playerSeed = fingerprint
+		}
+		if beeSeed == 0 {
+			beeSeed = fingerprint + 1
+		}
+	}
+	playerSeed = resolveSeed(playerSeed, 0)
+	beeSeed = resolveSeed(beeSeed, 1)
 	game := &Game{
-		Player:      &Player{HP: config.PlayerHP, MaxHP: config.PlayerHP},
-		Hive:        make(map[BeeType][]*Bee),
-		AliveBees:   make([]*Bee, 0, totalBees),
-		Turns:       0,
-		AutoMode:    false,
-		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
-		damageEvent: make(chan int, 10), // Buffered channel for damage events
-		Config:      config,
+		AutoMode:   false,
+		rng:        rand.New(rand.NewSource(playerSeed)),
+		beeRng:     rand.New(rand.NewSource(beeSeed)),
+		playerSeed: playerSeed,
+		beeSeed:    beeSeed,
+		Config:     config,
+	}
+
+	game.resetState()
+	if config.LogFile != "" {
+		game.logWriter = NewRotatingWriter(config.LogFile, config.LogFileMaxSize)
 	}
 
-	game.initializeHive()
+	// Start event-driven game stats monitor (skipped for lightweight games such as benchmarks)
+	if !config.DisableMonitor {
+		game.damageEvent = make(chan int, 10) // Buffered channel for damage events
+		game.monitorDone = make(chan struct{})
+		go game.monitorDamage(game.damageEvent, game.monitorDone)
+	}
 
-	// Start event-driven game stats monitor
-	go func() {
-		for damage := range game.damageEvent {
-			// Safely read game state with read lock
-			game.mu.RLock()
-			turns := game.Turns
-			playerHP := game.Player.HP
-			playerMaxHP := game.Player.MaxHP
-			game.mu.RUnlock()
-
-			if turns > 0 { // Only show stats after game starts
-				// Calculate values without holding lock to avoid deadlock
-				aliveBees := len(game.GetAliveBees())
-				survivalRate := float64(playerHP) / float64(playerMaxHP) * 100
-
-				// Show different messages based on damage severity
-				var damageIcon string
-				switch {
-				case damage >= 10:
-					damageIcon = "🩸" // High damage
-				case damage >= 5:
-					damageIcon = "⚡" // Medium damage
-				default:
-					damageIcon = "🔸" // Low damage
-				}
+	return game
+}
+
+// SeedEnvVar names the environment variable the command line reads a seed from when --seed isn't given
+const SeedEnvVar = "BEESINTHETRAP_SEED"
+
+// SeedFromEnv reads the seed from SeedEnvVar, reporting false when it is unset or empty
+func SeedFromEnv() (int64, bool, error) {
+	value := os.Getenv(SeedEnvVar)
+	if value == "" {
+		return 0, false, nil
+	}
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s must be an integer, got %q", SeedEnvVar, value)
+	}
+	return seed, true, nil
+}
+
+// gameplayFields holds, keyed by JSON name, the fields that decide how a game plays out.
+// The seeds, pacing, scoring and display options (e.g. TurnSummary or AutosavePath) are left
+// out. Fingerprint hashes these and challenge codes carry them, so new gameplay options belong here.
+func (c GameConfig) gameplayFields() map[string]any {
+	return map[string]any{
+		"player_hp":             c.PlayerHP,
+		"player_miss_chance":    c.PlayerMissChance,
+		"bees_miss_chance":      c.BeesMissChance,
+		"auto_strategy":         c.AutoStrategy,
+		"queen_count":           c.QueenCount,
+		"worker_count":          c.WorkerCount,
+		"drone_count":           c.DroneCount,
+		"player_armor":          c.PlayerArmor,
+		"armor_full_block":      c.ArmorFullBlock,
+		"min_bee_damage":        c.MinBeeDamage,
+		"splash_damage":         c.SplashDamage,
+		"block_window":          c.BlockWindow,
+		"passive_regen":         c.PassiveRegen,
+		"attacks_per_turn":      c.AttacksPerTurn,
+		"lifesteal":             c.Lifesteal,
+		"max_total_healing":     c.MaxTotalHealing,
+		"target_weights":        c.TargetWeights,
+		"item_drop_chance":      c.ItemDropChance,
+		"rage_meter_max":        c.RageMeterMax,
+		"special_damage":        c.SpecialDamage,
+		"cleave_charges":        c.CleaveCharges,
+		"max_stamina":           c.MaxStamina,
+		"stamina_regen":         c.StaminaRegen,
+		"gamble_win_chance":     c.GambleWinChance,
+		"revive_chance":         c.ReviveChance,
+		"lives":                 c.Lives,
+		"revive_hp":             c.ReviveHP,
+		"morale":                c.Morale,
+		"berserker":             c.Berserker,
+		"weapon_type":           c.WeaponType,
+		"resistances":           c.Resistances,
+		"bee_stats":             c.BeeStats,
+		"berserk_chance":        c.BerserkChance,
+		"queen_wipe_spares":     c.QueenWipeSpares,
+		"queen_shielded":        c.QueenShielded,
+		"disable_queen_wipe":    c.DisableQueenWipe,
+		"bee_leveling":          c.BeeLeveling,
+		"random_hive":           c.RandomHive,
+		"hive_ranges":           c.HiveRanges,
+		"swarm_pressure":        c.SwarmPressure,
+		"reinforcement_chance":  c.ReinforcementChance,
+		"max_reinforcements":    c.MaxReinforcements,
+		"sudden_death_turn":     c.SuddenDeathTurn,
+		"first_strike":          c.FirstStrike,
+		"adaptive_aggression":   c.AdaptiveAggression,
+		"workers_die_on_sting":  c.WorkersDieOnSting,
+		"two_player":            c.TwoPlayer,
+		"enrage_below":          c.EnrageBelow,
+		"name_hive":             c.NameHive,
+		"allow_flee":            c.AllowFlee,
+		"flee_chance":           c.FleeChance,
+		"flee_return_turns":     c.FleeReturnTurns,
+		"boss_rush":             c.BossRush,
+		"boss_rush_rounds":      c.BossRushRounds,
+		"max_bee_hits_per_turn": c.MaxBeeHitsPerTurn,
+		"healer_drones":         c.HealerDrones,
+		"mercy_rule":            c.MercyRule,
+	}
+}
+
+// Fingerprint hashes the gameplay fields into a stable, non-zero seed, so configs for the same game
+// always fingerprint the same (see SeedFromConfig)
+func (c GameConfig) Fingerprint() int64 {
+	gameplay := c.gameplayFields()
+	data, err := json.Marshal(gameplay) // Map keys encode sorted, so the bytes are stable
+	if err != nil {
+		// Only non-finite numbers fail to encode; fall back to their printed form
+		data = fmt.Appendf(nil, "%#v", gameplay)
+	}
+
+	hash := fnv.New64a()
+	hash.Write(data)
+	if fingerprint := int64(hash.Sum64()); fingerprint != 0 {
+		return fingerprint
+	}
+	return 1 // 0 means unseeded
+}
+
+// resolveSeed returns seed, falling back to the clock (plus offset so independent
+// generators created together don't share a seed) when seed is 0
+func resolveSeed(seed int64, offset int64) int64 {
+	if seed == 0 {
+		seed = time.Now().UnixNano() + offset
+	}
+	return seed
+}
+
+// out returns the writer used for game narration
+func (g *Game) out() io.Writer {
+	if g.Out == nil {
+		return os.Stdout
+	}
+	return g.Out
+}
+
+// in returns the reader interactive commands come from
+func (g *Game) in() io.Reader {
+	if g.In == nil {
+		return os.Stdin
+	}
+	return g.In
+}
 
-				fmt.Printf("%s Damage Alert: -%d HP | Turn %d | Player: %d/%d (%.1f%%) | Bees: %d\n",
-					damageIcon, damage, turns, playerHP, playerMaxHP, survivalRate, aliveBees)
+// inputScanner returns the shared line reader, creating it on first use outside PlayGame
+func (g *Game) inputScanner() *bufio.Scanner {
+	if g.input == nil {
+		g.input = bufio.NewScanner(g.in())
+	}
+	return g.input
+}
+
+// errInputTimeout is returned by readLine when InputTimeout passes without a line of input
+var errInputTimeout = errors.New("timed out waiting for input")
+
+// readLine reads the next line of input, returning io.EOF once input runs out.
+// With InputTimeout set, lines are read on a background goroutine so the wait can
+// give up with errInputTimeout, leaving the read to finish for the next prompt.
+func (g *Game) readLine() (string, error) {
+	return g.readLineWithin(time.Duration(g.Config.InputTimeout) * time.Millisecond)
+}
+
+// readLineWithin reads the next line like readLine, giving up with errInputTimeout after
+// the timeout (0 waits forever). Once any read has timed out the background reader owns
+// the input, so every later read goes through it too.
+func (g *Game) readLineWithin(timeout time.Duration) (string, error) {
+	scanner := g.inputScanner()
+	if timeout <= 0 && g.lines == nil {
+		if !scanner.Scan() {
+			return "", io.EOF
+		}
+		return scanner.Text(), nil
+	}
+
+	if g.lines == nil {
+		g.lines = make(chan string)
+		g.linesStop = make(chan struct{})
+		g.linesDone = make(chan struct{})
+		go func(lines chan<- string, stop <-chan struct{}, done chan<- struct{}) {
+			defer close(done)
+			defer close(lines)
+			for scanner.Scan() {
+				select {
+				case lines <- scanner.Text():
+				case <-stop:
+					return
+				}
 			}
+		}(g.lines, g.linesStop, g.linesDone)
+	}
+
+	var expired <-chan struct{}
+	if timeout > 0 {
+		expired = g.after(timeout)
+	}
+	select {
+	case line, ok := <-g.lines:
+		if !ok {
+			return "", io.EOF
 		}
-	}()
+		return line, nil
+	case <-expired:
+		return "", errInputTimeout
+	}
+}
 
-	return game
-} // initializeHive populates the hive with all the bees according to the game rules
+// stopInput tells the background reader, if one was started, to exit instead of waiting forever
+// to hand over a line nobody will ask for. A read already blocked on the input finishes first.
+func (g *Game) stopInput() {
+	if g.lines == nil {
+		return
+	}
+	close(g.linesStop)
+	g.lines = nil
+}
+
+// ringBell writes the ASCII bell to the output when the Bell option is on
+func (g *Game) ringBell() {
+	if g.Config.Bell {
+		fmt.Fprint(g.out(), "\a")
+	}
+}
+
+// monitorDamage prints live stats for every damage event until the channel is closed
+func (g *Game) monitorDamage(events <-chan int, done chan<- struct{}) {
+	defer close(done)
+	for damage := range events {
+		// Safely read game state with read lock
+		g.mu.RLock()
+		turns := g.Turns
+		playerHP := g.Player.HP
+		playerMaxHP := g.Player.MaxHP
+		out := g.monitorOut
+		g.mu.RUnlock()
+		if out == nil {
+			out = g.out()
+		}
+
+		if turns > 0 { // Only show stats after game starts
+			// Calculate values without holding lock to avoid deadlock
+			aliveBees := len(g.GetAliveBees())
+			survivalRate := float64(playerHP) / float64(playerMaxHP) * 100
+
+			fmt.Fprintf(out, "%s Damage Alert: -%d HP | Turn %d | Player: %d/%d (%.1f%%) | Bees: %d\n",
+				g.damageIcon(damage), damage, turns, playerHP, playerMaxHP, survivalRate, aliveBees)
+		}
+	}
+}
+
+// notifyDamage sends a damage event to the stats monitor without ever blocking the game
+func (g *Game) notifyDamage(damage int) {
+	out := g.out()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.damageEvent == nil || g.monitorClosed {
+		return // Monitor disabled or stopped
+	}
+	g.monitorOut = out
+
+	select {
+	case g.damageEvent <- damage:
+	default:
+		// Channel full, skip this event (non-blocking)
+	}
+}
+
+// Close stops the damage monitor, waiting for it to report any damage already sent, so nothing
+// is written once the game is done with. PlayGame closes the game when it returns; games driven
+// another way (Step, BeeTurn) should call Close themselves. Closing twice is harmless.
+func (g *Game) Close() {
+	g.mu.Lock()
+	if g.damageEvent == nil || g.monitorClosed {
+		g.mu.Unlock()
+		return
+	}
+	g.monitorClosed = true
+	close(g.damageEvent)
+	g.mu.Unlock()
+
+	<-g.monitorDone
+}
+
+// damageIcon picks the monitor icon for a damage value based on the configured thresholds
+func (g *Game) damageIcon(damage int) string {
+	high := g.Config.HighDamageThreshold
+	if high <= 0 {
+		high = DefaultHighDamageThreshold
+	}
+	medium := g.Config.MediumDamageThreshold
+	if medium <= 0 {
+		medium = DefaultMediumDamageThreshold
+	}
+
+	// Show different messages based on damage severity
+	switch {
+	case damage >= high:
+		return "🩸" // High damage
+	case damage >= medium:
+		return "⚡" // Medium damage
+	default:
+		return "🔸" // Low damage
+	}
+}
+
+// Reset puts the player and hive back to their starting state using the original configuration.
+// The damage monitor goroutine and its channel are reused so restarting never leaks goroutines.
+func (g *Game) Reset() {
+	g.mu.Lock()
+	g.resetState()
+	g.AutoMode = false
+	g.mu.Unlock()
+
+	g.eventsMu.Lock()
+	g.transcript = nil
+	g.turnIndex = nil
+	g.eventsMu.Unlock()
+}
+
+// resetState rebuilds the player, hive and turn counter (caller must hold the mutex or own the game)
+func (g *Game) resetState() {
+	totalBees := g.Config.QueenCount + g.Config.WorkerCount + g.Config.DroneCount
+
+	g.Player = &Player{HP: g.Config.PlayerHP, MaxHP: g.Config.PlayerHP}
+	g.Hive = make(map[BeeType][]*Bee)
+	g.AliveBees = make([]*Bee, 0, totalBees)
+	g.Turns = 0
+	g.Rage = 0
+	g.Reinforcements = 0
+	g.CleavesLeft = g.Config.CleaveCharges
+	g.Stamina = g.Config.MaxStamina
+	g.Morale = MoraleMax
+	g.Enraged = false
+	g.Revived = false
+	g.LivesLeft = g.Config.lives()
+	g.Fled = nil
+	g.Round = 1
+	g.DamageDealt = 0
+	g.DamageTaken = 0
+	g.TotalHealed = 0
+	g.nextBeeID = 0
+	g.nameCounts = make(map[string]int)
+	g.damageByBee = make(map[int]int)
+	g.damageByType = make(map[BeeType]TypeDamage)
+	g.HasShield = false
+
+	g.initializeHive()
+}
+
+// initializeHive populates the hive with all the bees according to the game rules
 func (g *Game) initializeHive() {
 	// Initialize the map slices
-	g.Hive[Queen] = make([]*Bee, 0, g.Config.QueenCount)
-	g.Hive[Worker] = make([]*Bee, 0, g.Config.WorkerCount)
-	g.Hive[Drone] = make([]*Bee, 0, g.Config.DroneCount)
+	queens := g.rollBeeCount(Queen, g.Config.QueenCount)
+	workers := g.rollBeeCount(Worker, g.Config.WorkerCount)
+	drones := g.rollBeeCount(Drone, g.Config.DroneCount)
+
+	g.Hive[Queen] = make([]*Bee, 0, queens)
+	g.Hive[Worker] = make([]*Bee, 0, workers)
+	g.Hive[Drone] = make([]*Bee, 0, drones)
+
+	// Add the Queen Bees
+	for i := 0; i < queens; i++ {
+		g.addBee(Queen)
+	}
+
+	// Add the Worker Bees
+	for i := 0; i < workers; i++ {
+		g.addBee(Worker)
+	}
+
+	// Add the Drone Bees, the first of them healers when HealerDrones is set
+	for i := 0; i < drones; i++ {
+		drone := g.addBee(Drone)
+		drone.Healer = i < g.Config.HealerDrones
+	}
+}
+
+// beeStats returns the stats bees of a type start with, preferring the BeeStats override
+func (c GameConfig) beeStats(beeType BeeType) BeeStats {
+	if stats, ok := c.BeeStats[beeType]; ok {
+		return stats
+	}
+	return BeeStatsTable[beeType]
+}
+
+// rollBeeCount picks how many bees of a type to create, rolling within the range for a random hive
+func (g *Game) rollBeeCount(beeType BeeType, fixed int) int {
+	r := g.Config.hiveRange(beeType, fixed)
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + g.beeRng.Intn(r.Max-r.Min+1)
+}
+
+// addBee creates a bee with the next free ID and places it in the hive (caller must hold the mutex or own the game)
+func (g *Game) addBee(beeType BeeType) *Bee {
+	g.nextBeeID++
+	stats := g.Config.beeStats(beeType)
+	bee := &Bee{Type: beeType, HP: stats.HP, MaxHP: stats.HP, Damage: stats.Damage}
+	bee.ID = g.nextBeeID
+	if g.Config.NameHive {
+		bee.Name = g.rollBeeName()
+	}
+	g.Hive[beeType] = append(g.Hive[beeType], bee)
+	g.AliveBees = append(g.AliveBees, bee)
+	return bee
+}
+
+// rollBeeName picks a name from BeeNames with the bee seed, numbering repeats so every bee stays distinct
+func (g *Game) rollBeeName() string {
+	name := BeeNames[g.beeRng.Intn(len(BeeNames))]
+	g.nameCounts[name]++
+	if count := g.nameCounts[name]; count > 1 {
+		return fmt.Sprintf("%s %d", name, count)
+	}
+	return name
+}
+
+// GetAliveBees gives you all the bees that are still alive. The slice is a copy, so callers
+// can iterate it safely while other goroutines keep playing.
+func (g *Game) GetAliveBees() []*Bee {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	aliveBees := g.getAliveBeesUnsafe()
+	return append(make([]*Bee, 0, len(aliveBees)), aliveBees...)
+}
+
+// getAliveBeesUnsafe is an internal helper that assumes the caller holds the mutex
+func (g *Game) getAliveBeesUnsafe() []*Bee {
+	// Rebuild the alive list by filtering out dead bees
+	aliveBees := make([]*Bee, 0, len(g.AliveBees))
+	for _, bee := range g.AliveBees {
+		if bee.IsAlive() {
+			aliveBees = append(aliveBees, bee)
+		}
+	}
+	g.AliveBees = aliveBees // Update the cached list
+	return aliveBees
+}
+
+// countAliveBeesUnsafe counts living bees across the hive (caller must hold the mutex)
+func (g *Game) countAliveBeesUnsafe() int {
+	count := 0
+	for _, beeList := range g.Hive {
+		for _, bee := range beeList {
+			if bee.IsAlive() {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// MostDangerousBee returns the bee that has dealt the player the most damage and how much,
+// breaking ties by the lowest ID (nil if no sting has landed yet)
+func (g *Game) MostDangerousBee() (*Bee, int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	mvpID, mvpDamage := 0, 0
+	for id, damage := range g.damageByBee {
+		if damage > mvpDamage || (damage == mvpDamage && id < mvpID) {
+			mvpID, mvpDamage = id, damage
+		}
+	}
+	if mvpDamage == 0 {
+		return nil, 0
+	}
+
+	// A bee that has fled still counts, since it will be back
+	if bee, _ := g.findBeeUnsafe(mvpID); bee != nil {
+		return bee, mvpDamage
+	}
+	return nil, 0
+}
+
+// findBeeUnsafe looks a bee up by ID in the hive and then among the bees that have fled,
+// also returning its FledBee entry while it is away (caller must hold the mutex)
+func (g *Game) findBeeUnsafe(id int) (*Bee, *FledBee) {
+	for _, beeList := range g.Hive {
+		for _, bee := range beeList {
+			if bee.ID == id {
+				return bee, nil
+			}
+		}
+	}
+	for i := range g.Fled {
+		if g.Fled[i].Bee.ID == id {
+			return g.Fled[i].Bee, &g.Fled[i]
+		}
+	}
+	return nil, nil
+}
+
+// TypeDamage is how much HP the player has stripped from one bee type and how many of them they killed
+type TypeDamage struct {
+	HPRemoved int `json:"hp_removed"`
+	Kills     int `json:"kills"`
+}
+
+// damageBeeUnsafe strips up to amount HP from a bee on the player's behalf, crediting what was
+// actually removed to DamageDealt and the bee type's breakdown (caller must hold the mutex)
+func (g *Game) damageBeeUnsafe(bee *Bee, amount int) {
+	removed := bee.TakeDamageAmount(amount)
+	g.DamageDealt += removed
+
+	breakdown := g.damageByType[bee.Type]
+	breakdown.HPRemoved += removed
+	if removed > 0 && !bee.IsAlive() {
+		breakdown.Kills++
+	}
+	g.damageByType[bee.Type] = breakdown
+}
+
+// DamageByType reports, for each bee type the player has damaged, the HP stripped and the kills
+func (g *Game) DamageByType() map[BeeType]TypeDamage {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	breakdown := make(map[BeeType]TypeDamage, len(g.damageByType))
+	for beeType, damage := range g.damageByType {
+		breakdown[beeType] = damage
+	}
+	return breakdown
+}
+
+// SnapshotHive copies every bee, dead or alive, by value so callers can inspect the hive
+// without being able to touch live game state
+func (g *Game) SnapshotHive() map[BeeType][]Bee {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	snapshot := make(map[BeeType][]Bee, len(g.Hive))
+	for beeType, beeList := range g.Hive {
+		bees := make([]Bee, len(beeList))
+		for i, bee := range beeList {
+			bees[i] = *bee.Clone()
+		}
+		snapshot[beeType] = bees
+	}
+	return snapshot
+}
+
+// totalBees counts every bee the hive has had this game, dead or alive (reinforcements included)
+func (g *Game) totalBees() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	total := 0
+	for _, beeList := range g.Hive {
+		total += len(beeList)
+	}
+	return total
+}
+
+// GetBeesByType finds all living bees of a particular type (O(1) map access to type group).
+// The bees always come back sorted by ascending ID, whatever order the hive keeps them in
+// (fled bees, for one, rejoin at the end of their group).
+func (g *Game) GetBeesByType(beeType BeeType) []*Bee {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var bees []*Bee
+	for _, bee := range g.Hive[beeType] {
+		if bee.IsAlive() {
+			bees = append(bees, bee)
+		}
+	}
+	sort.Slice(bees, func(i, j int) bool { return bees[i].ID < bees[j].ID })
+	return bees
+}
+
+// IsGameOver checks if someone has won or lost the game. Bees that fled with AllowFlee don't
+// count: clearing the hive wins even while some are still due back, as they forfeit the fight.
+func (g *Game) IsGameOver() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	// Player is dead
+	if !g.Player.IsAlive() {
+		return true
+	}
+
+	// All bees are dead - use internal method to avoid double locking
+	aliveBees := g.getAliveBeesUnsafe()
+	return len(aliveBees) == 0
+}
+
+// KillAllBees wipes out the entire hive (happens when the Queen dies)
+func (g *Game) KillAllBees() {
+	g.KillAllBeesExcept()
+}
+
+// KillAllBeesExcept wipes out the hive but leaves every bee of the spared types alive
+func (g *Game) KillAllBeesExcept(spared ...BeeType) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	isSpared := make(map[BeeType]bool, len(spared))
+	for _, beeType := range spared {
+		isSpared[beeType] = true
+	}
+
+	for beeType, beeList := range g.Hive {
+		if isSpared[beeType] {
+			continue
+		}
+		for _, bee := range beeList {
+			if bee.IsAlive() {
+				g.damageBeeUnsafe(bee, bee.HP)
+			}
+		}
+	}
+
+	if len(spared) == 0 {
+		g.AliveBees = []*Bee{} // Clear the alive list
+		return
+	}
+	g.rebuildAliveBeesUnsafe()
+}
+
+// rebuildAliveBeesUnsafe repopulates the alive cache from the hive in Queen, Worker, Drone order
+// (caller must hold the mutex)
+func (g *Game) rebuildAliveBeesUnsafe() {
+	aliveBees := make([]*Bee, 0, len(g.AliveBees))
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		for _, bee := range g.Hive[beeType] {
+			if bee.IsAlive() {
+				aliveBees = append(aliveBees, bee)
+			}
+		}
+	}
+	g.AliveBees = aliveBees
+}
+
+// PrintGameStatus shows the current state of the battle
+func (g *Game) PrintGameStatus() {
+	// One snapshot keeps the counts consistent even if another goroutine is mid-turn
+	status := g.Status()
+
+	fmt.Fprintf(g.out(), "\n=== Game Status ===\n")
+	fmt.Fprintf(g.out(), "Player HP: %d/%d\n", status.PlayerHP, status.PlayerMaxHP)
+
+	fmt.Fprintf(g.out(), "Alive Bees:\n")
+	fmt.Fprintf(g.out(), "  Queens: %d\n", status.Queens)
+	fmt.Fprintf(g.out(), "  Workers: %d\n", status.Workers)
+	fmt.Fprintf(g.out(), "  Drones: %d\n", status.Drones)
+	fmt.Fprintf(g.out(), "Turns: %d\n", status.Turns)
+	fmt.Fprintln(g.out(), "==================")
+}
+
+// commandVerb is the first word of a command, which says what it does ("" for a blank command)
+func commandVerb(command string) string {
+	if fields := strings.Fields(command); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// parseInspect reads the bee ID from an 'inspect <id>' command
+func parseInspect(command string) (int, error) {
+	fields := strings.Fields(command)
+	if len(fields) != 2 || fields[0] != "inspect" {
+		return 0, fmt.Errorf("usage: inspect <id>")
+	}
+	id, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a bee ID", fields[1])
+	}
+	return id, nil
+}
+
+// InspectBee prints one living bee's type, HP, damage and (with leveling on) level, without using a turn
+func (g *Game) InspectBee(id int) {
+	g.mu.RLock()
+	var found *Bee
+	away, returnTurn := false, 0
+	if bee, fled := g.findBeeUnsafe(id); bee != nil {
+		found = bee.Clone()
+		if fled != nil {
+			away, returnTurn = true, fled.ReturnTurn
+		}
+	}
+	g.mu.RUnlock()
+
+	switch {
+	case found == nil:
+		fmt.Fprintf(g.out(), "There is no bee #%d in the hive.\n", id)
+	case away && g.Config.FleeReturnTurns > 0:
+		fmt.Fprintf(g.out(), "%s (#%d) has fled the fight with %d HP and returns on turn %d.\n", found.describe("The"), id, found.HP, returnTurn)
+	case away:
+		fmt.Fprintf(g.out(), "%s (#%d) has fled the fight with %d HP.\n", found.describe("The"), id, found.HP)
+	case !found.IsAlive():
+		fmt.Fprintf(g.out(), "%s (#%d) is already dead.\n", found.describe("The"), id)
+	default:
+		fmt.Fprintf(g.out(), "\n=== Bee #%d ===\n", id)
+		if found.Name != "" {
+			fmt.Fprintf(g.out(), "Name: %s\n", found.Name)
+		}
+		fmt.Fprintf(g.out(), "Type: %s\n", found.Type)
+		fmt.Fprintf(g.out(), "HP: %d/%d\n", found.HP, found.MaxHP)
+		fmt.Fprintf(g.out(), "Damage: %d\n", found.AttackDamage())
+		if g.Config.BeeLeveling {
+			fmt.Fprintf(g.out(), "Level: %d/%d\n", found.Level, BeeMaxLevel)
+		}
+	}
+}
+
+// RevealHive prints every living bee's ID and exact HP (debug aid, doesn't use a turn)
+func (g *Game) RevealHive() {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	fmt.Fprintln(g.out(), "\n=== Hive Reveal ===")
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		for _, bee := range g.Hive[beeType] {
+			if bee.IsAlive() {
+				label := bee.Type.String()
+				if bee.Name != "" {
+					label = bee.describe("a")
+				}
+				fmt.Fprintf(g.out(), "  #%d %s: %d/%d HP\n", bee.ID, label, bee.HP, bee.MaxHP)
+			}
+		}
+	}
+	fmt.Fprintln(g.out(), "===================")
+}
+
+// Start welcomes the player and shows them what's happening
+func (g *Game) Start() {
+	fmt.Fprintln(g.out(), "Welcome to Bees in the Trap!")
+	fmt.Fprintln(g.out(), "Your mission: Destroy the hive before the bees sting you to death!")
+	fmt.Fprintln(g.out(), "Type 'hit' to attack the hive, or 'auto' to let the game run automatically.")
+	if g.Config.RandomHive {
+		fmt.Fprintf(g.out(), "🎲 This hive rolled %d Queens, %d Workers and %d Drones.\n",
+			len(g.Hive[Queen]), len(g.Hive[Worker]), len(g.Hive[Drone]))
+	}
+	g.PrintGameStatus()
+}
+
+// PlayGame keeps the game running until someone wins or loses, or the player quits,
+// and returns how the game stands when it stops
+func (g *Game) PlayGame() GameResult {
+	defer g.Close()
+
+	g.stopInput()
+	defer g.stopInput()
+	g.input = bufio.NewScanner(g.in())
+	g.interactive = true
+	defer func() { g.interactive = false }()
+
+	for !g.IsGameOver() {
+		if g.AutoMode {
+			// Let the computer play automatically
+			g.playRound(g.autoCommand())
+			if g.Config.AutoModeDelay > 0 {
+				g.clock().Sleep(time.Duration(g.Config.AutoModeDelay) * time.Millisecond) // Small pause so you can follow along
+			}
+		} else {
+			// Wait for the player to tell us what to do
+			prompt := g.Config.Prompt
+			if prompt == "" {
+				prompt = DefaultPrompt
+			}
+			fmt.Fprint(g.out(), "\n"+prompt)
+			line, err := g.readLine()
+			if errors.Is(err, errInputTimeout) {
+				fmt.Fprintf(g.out(), "\n⏰ No command after %dms - playing a turn for you.\n", g.Config.InputTimeout)
+				g.playRound(g.autoCommand())
+				continue
+			}
+			if err != nil {
+				break
+			}
+
+			input := strings.TrimSpace(strings.ToLower(line))
+			if command, ok := commandAbbreviations[input]; ok {
+				input = command
+			}
+
+			// Recall the previous command so players don't have to retype it
+			if input == "!!" || input == "up" {
+				if g.LastCommand == "" {
+					fmt.Fprintln(g.out(), "No previous command to repeat.")
+					continue
+				}
+				fmt.Fprintf(g.out(), "Repeating '%s'...\n", g.LastCommand)
+				input = g.LastCommand
+			}
+
+			// Commands are told apart by their first word; a build name keeps the case it was typed in
+			if commandVerb(input) == "savebuild" {
+				name, err := parseSaveBuild(strings.TrimSpace(line))
+				if err == nil {
+					err = SaveBuild(g.buildsDir(), name, g.Config)
+				}
+				if err != nil {
+					fmt.Fprintf(g.out(), "Can't save build: %v.\n", err)
+					continue
+				}
+				fmt.Fprintf(g.out(), "💾 Saved this build as '%s'. Start with it using --load-build %s\n", name, name)
+				continue
+			}
+
+			if commandVerb(input) == "aim" {
+				if !g.HasStamina() {
+					fmt.Fprintln(g.out(), "😮‍💨 You're out of stamina! Type 'rest' to catch your breath.")
+					continue
+				}
+				id, ok := g.promptAim(input)
+				if !ok {
+					continue
+				}
+				g.LastCommand = "aim"
+				g.playRound(fmt.Sprintf("aim %d", id))
+				continue
+			}
+
+			if commandVerb(input) == "inspect" {
+				id, err := parseInspect(input)
+				if err != nil {
+					fmt.Fprintf(g.out(), "Can't inspect: %v.\n", err)
+					continue
+				}
+				g.InspectBee(id)
+				continue
+			}
+
+			if commandVerb(input) == "cleave" {
+				if _, err := g.parseCleave(input); err != nil {
+					fmt.Fprintf(g.out(), "Can't cleave: %v.\n", err)
+					continue
+				}
+				g.LastCommand = input
+				g.playRound(input)
+				continue
+			}
+
+			switch input {
+			case "hit", "finish", "gamble":
+				if !g.HasStamina() {
+					fmt.Fprintln(g.out(), "😮‍💨 You're out of stamina! Type 'rest' to catch your breath.")
+					continue
+				}
+				g.LastCommand = input
+				g.playRound(input)
+			case "rest", "wait":
+				g.playRound(input)
+			case "special":
+				if !g.SpecialReady() {
+					rage, rageMax := g.rageMeter()
+					fmt.Fprintf(g.out(), "Your rage meter isn't full yet (%d/%d). Take more stings to unlock 'special'.\n", rage, rageMax)
+					continue
+				}
+				g.LastCommand = input
+				g.playRound(input)
+			case "auto":
+				fmt.Fprintln(g.out(), "Switching to auto mode...")
+				g.AutoMode = true
+				continue
+			case "status":
+				g.PrintGameStatus()
+				continue
+			case "help":
+				g.PrintHelp()
+				continue
+			case "odds":
+				g.PrintOdds()
+				continue
+			case "map":
+				fmt.Fprint(g.out(), g.RenderHiveMap())
+				continue
+			case "challenge":
+				fmt.Fprintf(g.out(), "Challenge code: %s\n", g.ChallengeCode())
+				fmt.Fprintln(g.out(), "Share it and play the same scenario with --challenge <code>")
+				continue
+			case "reveal":
+				if !g.Config.DebugMode {
+					fmt.Fprintln(g.out(), "The 'reveal' command is only available in debug mode.")
+					continue
+				}
+				g.RevealHive()
+				continue
+			case "restart":
+				fmt.Fprintln(g.out(), "Restarting the game...")
+				g.Reset()
+				g.PrintGameStatus()
+				continue
+			case "quit":
+				fmt.Fprintln(g.out(), "Thanks for playing!")
+				return g.Result()
+			default:
+				fmt.Fprintln(g.out(), "Invalid command. Use 'hit', 'finish', 'special', 'auto', 'status', 'restart', 'help', or 'quit' (or h/f/a/s/?/q).")
+				continue
+			}
+		}
+	}
+
+	g.EndGame()
+	return g.Result()
+}
+
+// playRound plays one full turn and autosaves the result
+func (g *Game) playRound(command string) {
+	g.playPhases(command)
+	if g.AutoMode || g.Config.TurnSummary {
+		fmt.Fprintln(g.out(), g.TurnSummary(g.currentTurn()))
+	}
+	if g.Config.ShowProgress {
+		fmt.Fprintf(g.out(), "Hive health: %s\n", g.HiveHealthBar(HiveHealthBarWidth))
+	}
+	g.autosave()
+}
+
+// playPhases runs both halves of a turn in first strike order, stopping once the game is decided
+func (g *Game) playPhases(command string) {
+	defer g.advanceBossRush()
+
+	if g.Config.FirstStrike == FirstStrikeBees {
+		// The hive strikes first, then the player answers if they survived
+		g.beginTurn()
+		g.BeeTurn()
+		if g.IsGameOver() {
+			return
+		}
+		g.playerAction(command)
+		return
+	}
+
+	g.PlayerTurn(command)
+
+	// See if the game ended after the player's turn
+	if g.IsGameOver() {
+		return
+	}
+
+	// Now it's the bees' turn to fight back
+	g.BeeTurn()
+}
+
+// Step plays one full turn for programmatic callers (servers, bots) without reading stdin.
+// Concurrent calls are serialized so turns never interleave.
+// A command that can't be played returns a *CommandError and leaves the game untouched.
+func (g *Game) Step(command string) error {
+	g.turnMu.Lock()
+	defer g.turnMu.Unlock()
+
+	if g.IsGameOver() {
+		return ErrGameOver
+	}
+
+	if commandVerb(command) == "cleave" {
+		if _, err := g.parseCleave(command); err != nil {
+			return err
+		}
+		g.playRound(command)
+		return nil
+	}
+
+	if commandVerb(command) == "aim" {
+		id, err := parseAim(command)
+		if err != nil {
+			return &CommandError{Command: command, Kind: CommandInvalid, Err: err}
+		}
+		if g.livingBee(id) == nil {
+			return &CommandError{Command: command, Kind: CommandNoTargets, Err: fmt.Errorf("no living bee has ID %d", id)}
+		}
+		if !g.HasStamina() {
+			return &CommandError{Command: command, Kind: CommandUnavailable, Err: ErrNoStamina}
+		}
+		g.playRound(command)
+		return nil
+	}
+
+	switch command {
+	case "hit", "finish", "gamble":
+		if !g.HasStamina() {
+			return &CommandError{Command: command, Kind: CommandUnavailable, Err: ErrNoStamina}
+		}
+		g.playRound(command)
+		return nil
+	case "rest", "wait":
+		g.playRound(command)
+		return nil
+	case "special":
+		if !g.SpecialReady() {
+			return &CommandError{Command: command, Kind: CommandUnavailable, Err: ErrSpecialNotReady}
+		}
+		g.playRound(command)
+		return nil
+	default:
+		return &CommandError{Command: command, Kind: CommandUnknown, Err: fmt.Errorf("unknown command %q", command)}
+	}
+}
+
+// Status summarizes a consistent Snapshot of the player and hive
+func (g *Game) Status() GameStatus {
+	snapshot := g.Snapshot()
+	status := GameStatus{
+		Turns:       snapshot.Turn,
+		PlayerHP:    snapshot.PlayerHP,
+		PlayerMaxHP: snapshot.PlayerMaxHP,
+		AliveBees:   len(snapshot.Bees),
+	}
+	for _, bee := range snapshot.Bees {
+		switch bee.Type {
+		case Queen:
+			status.Queens++
+		case Worker:
+			status.Workers++
+		case Drone:
+			status.Drones++
+		}
+	}
+	status.GameOver = snapshot.PlayerHP <= 0 || status.AliveBees == 0
+	return status
+}
+
+// Process exit codes reported by the command line game, see GameResult.ExitCode
+const (
+	ExitWin        = 0 // The player destroyed the hive and survived
+	ExitLoss       = 1 // The bees stung the player to death
+	ExitDraw       = 2 // The player and the last of the hive fell together
+	ExitUnfinished = 3 // The player quit or input ran out before the game was decided
+	ExitError      = 4 // The configuration was invalid or the game couldn't run
+
+	ExitOK = 0 // A run that plays no game (help, estimates, printing the config) finished
+)
+
+// ExitCode maps the outcome to a process exit code so games can be scripted in shells and CI
+func (r GameResult) ExitCode() int {
+	switch {
+	case !r.Over:
+		return ExitUnfinished
+	case r.MutualDefeat:
+		return ExitDraw
+	case r.PlayerWon:
+		return ExitWin
+	default:
+		return ExitLoss
+	}
+}
+
+// Result reports the outcome, detecting a mutual defeat when the player and hive are both gone
+func (g *Game) Result() GameResult {
+	status := g.Status()
+	playerDead := status.PlayerHP <= 0
+	hiveDead := status.AliveBees == 0
+
+	return GameResult{
+		Over:          playerDead || hiveDead,
+		PlayerWon:     hiveDead && !playerDead,
+		MutualDefeat:  hiveDead && playerDead,
+		Turns:         status.Turns,
+		PlayerHP:      status.PlayerHP,
+		BeesRemaining: status.AliveBees,
+	}
+}
+
+// StatusJSON returns the current Status encoded as JSON
+func (g *Game) StatusJSON() ([]byte, error) {
+	return json.Marshal(g.Status())
+}
+
+// PlayerTurn lets the player do something on their turn
+func (g *Game) PlayerTurn(command string) {
+	g.beginTurn()
+	g.playerAction(command)
+}
+
+// playerAction resolves the player's half of the current turn
+func (g *Game) playerAction(command string) {
+	g.runBeforeTurn()
+	fmt.Fprintf(g.out(), "\n--- Turn %d: Player Turn ---\n", g.currentTurn())
+
+	// Passive regeneration kicks in before the player acts
+	if healed := g.healPlayer(g.Config.PassiveRegen); healed > 0 {
+		playerHP, playerMaxHP := g.playerHealth()
+		fmt.Fprintf(g.out(), "💚 You regenerate %d HP (%d/%d).\n", healed, playerHP, playerMaxHP)
+	}
+
+	g.regenStamina()
+
+	if commandVerb(command) == "cleave" {
+		if beeType, err := g.parseCleave(command); err == nil {
+			g.Cleave(beeType)
+		}
+		return
+	}
+
+	var attack func()
+	switch command {
+	case "hit":
+		attack = g.PlayerAttack
+	case "finish":
+		attack = g.FinishAttack
+	case "rest":
+		g.rest()
+		return
+	case "wait":
+		fmt.Fprintln(g.out(), "⏳ You hold still and let the bees make their move.")
+		return
+	case "special":
+		g.SpecialAttack()
+		return
+	case "gamble":
+		attack = g.Gamble
+	default:
+		id, err := parseAim(command)
+		if err != nil {
+			return
+		}
+		attack = func() { g.AimAttack(id) }
+	}
+
+	if !g.spendStamina() {
+		fmt.Fprintln(g.out(), "😮‍💨 You're too exhausted to attack! Type 'rest' to catch your breath.")
+		return
+	}
+
+	attacks := g.Config.AttacksPerTurn
+	if attacks < 1 {
+		attacks = 1
+	}
+	for i := 0; i < attacks; i++ {
+		// Stop swinging once the hive is gone (e.g. the Queen wipe mid-sequence)
+		if i > 0 && len(g.GetAliveBees()) == 0 {
+			break
+		}
+		attack()
+	}
+}
+
+// incrementTurn safely advances the turn counter and returns the new turn number
+func (g *Game) incrementTurn() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Turns++
+	return g.Turns
+}
+
+// bossRushRounds is how many rounds a boss rush lasts, falling back to the default
+func (c GameConfig) bossRushRounds() int {
+	if c.BossRushRounds <= 0 {
+		return DefaultBossRushRounds
+	}
+	return c.BossRushRounds
+}
+
+// advanceBossRush starts the next boss rush round once the hive is cleared,
+// summoning a lone Queen scaled up by BossRushScaling for every round already won
+func (g *Game) advanceBossRush() {
+	if !g.Config.BossRush {
+		return
+	}
+
+	g.mu.Lock()
+	if !g.Player.IsAlive() || g.countAliveBeesUnsafe() > 0 || g.Round >= g.Config.bossRushRounds() {
+		g.mu.Unlock()
+		return
+	}
+	g.Round++
+	round := g.Round
+	scale := 1 + BossRushScaling*float64(round-1)
+	boss := g.addBee(Queen)
+	boss.MaxHP = int(math.Round(float64(boss.MaxHP) * scale))
+	boss.HP = boss.MaxHP
+	boss.Damage = int(math.Round(float64(boss.Damage) * scale))
+	g.mu.Unlock()
+
+	fmt.Fprintf(g.out(), "\n👑 BOSS RUSH - Round %d of %d! %s (#%d) rises with %d HP and a %d damage sting!\n",
+		round, g.Config.bossRushRounds(), boss.describe("A"), boss.ID, boss.HP, boss.Damage)
+	g.emit(GameEvent{Action: EventBossRound, Actor: "hive", Target: boss.Type.String(), TargetHP: boss.HP, BeeID: boss.ID})
+}
+
+// beginTurn advances the turn counter and brings back any fled bees due to return
+func (g *Game) beginTurn() {
+	g.incrementTurn()
+	g.returnFledBees()
+}
+
+// currentTurn safely reads the turn counter
+// (state snapshots that read several fields at once read Turns under their own lock instead)
+func (g *Game) currentTurn() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Turns
+}
+
+// HasStamina reports whether the player can afford an attack (always true without stamina)
+func (g *Game) HasStamina() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Config.MaxStamina <= 0 || g.Stamina > 0
+}
+
+// spendStamina pays for one attacking turn, reporting false if the player is exhausted
+func (g *Game) spendStamina() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.Config.MaxStamina <= 0 {
+		return true
+	}
+	if g.Stamina <= 0 {
+		return false
+	}
+	g.Stamina--
+	return true
+}
+
+// regenStamina restores the per-turn stamina regeneration, capped at the maximum
+func (g *Game) regenStamina() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.Config.MaxStamina <= 0 || g.Config.StaminaRegen <= 0 {
+		return
+	}
+	g.Stamina = min(g.Stamina+g.Config.StaminaRegen, g.Config.MaxStamina)
+}
+
+// rest skips the player's offense to refill their stamina
+func (g *Game) rest() {
+	g.mu.Lock()
+	g.Stamina = g.Config.MaxStamina
+	stamina := g.Stamina
+	g.mu.Unlock()
+
+	if g.Config.MaxStamina <= 0 {
+		fmt.Fprintln(g.out(), "😮‍💨 You hold back and catch your breath.")
+		return
+	}
+	fmt.Fprintf(g.out(), "😮‍💨 You rest and catch your breath (stamina %d/%d).\n", stamina, g.Config.MaxStamina)
+}
+
+// Auto mode strategies for AutoStrategy
+const (
+	AutoStrategyRandom       = "random"        // Hit a random bee (the default)
+	AutoStrategyFocusQueen   = "focus-queen"   // Aim every attack at the Queen while she lives
+	AutoStrategyWeakest      = "weakest"       // Finish off the weakest bee
+	AutoStrategyCleaveDrones = "cleave-drones" // Spend cleaves on the Drones, then hit
+)
+
+// autoCommand picks what auto mode and simulations play: rest when exhausted, otherwise the AutoStrategy's attack
+func (g *Game) autoCommand() string {
+	if !g.HasStamina() {
+		return "rest"
+	}
+
+	switch g.Config.AutoStrategy {
+	case AutoStrategyFocusQueen:
+		if queens := g.GetBeesByType(Queen); len(queens) > 0 {
+			return fmt.Sprintf("aim %d", queens[0].ID)
+		}
+	case AutoStrategyWeakest:
+		return "finish"
+	case AutoStrategyCleaveDrones:
+		if _, err := g.parseCleave("cleave drone"); err == nil {
+			return "cleave drone"
+		}
+	}
+	return "hit"
+}
+
+// healPlayer safely heals the player and reports how much HP was actually restored,
+// refusing anything past MaxTotalHealing
+func (g *Game) healPlayer(amount int) int {
+	g.mu.Lock()
+	refused := false
+	if limit := g.Config.MaxTotalHealing; limit > 0 && amount > 0 {
+		remaining := max(limit-g.TotalHealed, 0)
+		refused = remaining == 0 && g.Player.HP < g.Player.MaxHP
+		amount = min(amount, remaining)
+	}
+	hpBefore := g.Player.HP
+	g.Player.Heal(amount)
+	healed := g.Player.HP - hpBefore
+	g.TotalHealed += healed
+	g.mu.Unlock()
+
+	if refused {
+		fmt.Fprintf(g.out(), "💔 Your healing is exhausted - all %d HP of it has been used this game.\n", g.Config.MaxTotalHealing)
+	}
+	return healed
+}
+
+// playerHealth safely reads the player's current and maximum HP
+func (g *Game) playerHealth() (int, int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.Player.HP, g.Player.MaxHP
+}
+
+// PlayerAttack makes the player swing at the hive
+func (g *Game) PlayerAttack() {
+	g.attackTarget(g.selectTarget)
+}
+
+// Gamble stakes the attack on a coin flip: win and a random bee takes double damage,
+// lose and the attack whiffs entirely
+func (g *Game) Gamble() {
+	aliveBees := g.GetAliveBees()
+	if len(aliveBees) == 0 {
+		fmt.Fprintln(g.out(), "No bees left to attack!")
+		return
+	}
+
+	winChance := g.Config.GambleWinChance
+	if winChance <= 0 {
+		winChance = DefaultGambleWinChance
+	}
+	if g.rng.Float64() >= winChance {
+		fmt.Fprintln(g.out(), "🎲 Double or nothing... NOTHING! Your attack whiffs completely.")
+		g.emit(GameEvent{Action: EventPlayerMiss, Actor: "player"})
+		return
+	}
+
+	fmt.Fprintln(g.out(), "🎲 Double or nothing... DOUBLE! Your strike lands with twice the force!")
+	g.strikeBee(g.selectTarget(aliveBees), GambleDamageMultiplier)
+}
+
+// FinishAttack makes the player go for the weakest living bee to secure a kill
+func (g *Game) FinishAttack() {
+	g.attackTarget(weakestBee)
+}
+
+// AimAttack attacks the living bee with the given ID, or a target chosen as for 'hit' if it's gone
+func (g *Game) AimAttack(id int) {
+	g.attackTarget(func(aliveBees []*Bee) *Bee {
+		for _, bee := range aliveBees {
+			if bee.ID == id {
+				return bee
+			}
+		}
+		return g.selectTarget(aliveBees)
+	})
+}
+
+// parseAim reads the bee ID from an 'aim <id>' command
+func parseAim(command string) (int, error) {
+	fields := strings.Fields(command)
+	if len(fields) != 2 || fields[0] != "aim" {
+		return 0, fmt.Errorf("usage: aim <id>")
+	}
+	id, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a bee ID", fields[1])
+	}
+	return id, nil
+}
+
+// livingBee finds the living bee with the given ID (nil if there's none)
+func (g *Game) livingBee(id int) *Bee {
+	for _, bee := range g.GetAliveBees() {
+		if bee.ID == id {
+			return bee
+		}
+	}
+	return nil
+}
+
+// promptAim picks the bee an 'aim' command attacks: the ID given with the command if it's alive,
+// otherwise one chosen from a list of the living bees. Bad picks are asked again without using
+// the turn; running out of input cancels the aim.
+func (g *Game) promptAim(command string) (int, bool) {
+	if id, err := parseAim(command); err == nil && g.livingBee(id) != nil {
+		return id, true
+	}
+
+	fmt.Fprintln(g.out(), "🎯 Living bees:")
+	for _, bee := range g.GetAliveBees() {
+		fmt.Fprintf(g.out(), "  #%-3d %-20s %d/%d HP\n", bee.ID, bee.describe("a"), bee.HP, bee.MaxHP)
+	}
+	for {
+		fmt.Fprint(g.out(), "Aim at which bee ID? ")
+		line, err := g.readLine()
+		if err != nil {
+			fmt.Fprintln(g.out(), "\nAim cancelled.")
+			return 0, false
+		}
+		input := strings.TrimSpace(line)
+		if id, err := strconv.Atoi(strings.TrimPrefix(input, "#")); err == nil && g.livingBee(id) != nil {
+			return id, true
+		}
+		fmt.Fprintf(g.out(), "No living bee has ID %q.\n", input)
+	}
+}
+
+// attackTarget rolls for a miss and then hits whichever bee choose picks from the living hive
+func (g *Game) attackTarget(choose func([]*Bee) *Bee) {
+	aliveBees := g.GetAliveBees()
+	if len(aliveBees) == 0 {
+		fmt.Fprintln(g.out(), "No bees left to attack!")
+		return
+	}
+
+	// Sometimes you miss completely
+	if g.rng.Float64() < g.EffectiveMissChance() {
+		fmt.Fprintln(g.out(), "Miss! You just missed the hive, better luck next time!")
+		g.emit(GameEvent{Action: EventPlayerMiss, Actor: "player"})
+		return
+	}
+
+	// Pick the bee to hit
+	g.strikeBee(choose(aliveBees), 1)
+}
+
+// strikeBee lands a hit on the bee, dealing its usual damage times multiplier, and resolves any kill
+func (g *Game) strikeBee(targetBee *Bee, multiplier int) {
+	fmt.Fprintf(g.out(), "Direct Hit! You attacked %s!\n", targetBee.describe("a"))
+
+	// A shielded Queen can't be hurt while any of her Workers still live
+	if workers := g.shieldingWorkers(targetBee); workers > 0 {
+		fmt.Fprintf(g.out(), "🛡️ Your blow glances off! %s is shielded while her %d Workers live - clear them first!\n", targetBee.describe("The"), workers)
+		g.emit(GameEvent{Action: EventPlayerHit, Actor: "player", Target: targetBee.Type.String(), TargetHP: targetBee.HP, BeeID: targetBee.ID})
+		return
+	}
+	if resist := g.resistanceMultiplier(targetBee.Type); resist > 1 {
+		fmt.Fprintf(g.out(), "💢 %s is weak to %s!\n", targetBee.describe("The"), g.Config.WeaponType)
+	} else if resist < 1 {
+		fmt.Fprintf(g.out(), "🪨 %s resists %s damage.\n", targetBee.describe("The"), g.Config.WeaponType)
+	}
+
+	// Splash neighbours are picked before the hit, while the target still holds its place among its type
+	splashed := g.splashTargets(targetBee)
+	defer g.splash(splashed)
+
+	// Hit the bee
+	damage := g.getDamageDealtTo(targetBee.Type) * multiplier
+	g.mu.Lock()
+	g.damageBeeUnsafe(targetBee, damage)
+	g.mu.Unlock()
+
+	if !targetBee.IsAlive() {
+		fmt.Fprintf(g.out(), "You killed %s! (%d damage dealt)\n", targetBee.describe("the"), damage)
+		g.beeKilled(damage, targetBee)
+	} else {
+		fmt.Fprintf(g.out(), "%s took %d damage and has %d HP remaining.\n", targetBee.describe("The"), damage, targetBee.HP)
+		g.emit(GameEvent{Action: EventPlayerHit, Actor: "player", Target: targetBee.Type.String(), Damage: damage, TargetHP: targetBee.HP, BeeID: targetBee.ID})
+	}
+}
+
+// shieldingWorkers counts the living Workers keeping a QueenShielded Queen from harm,
+// or 0 when the bee isn't a shielded Queen
+func (g *Game) shieldingWorkers(bee *Bee) int {
+	if bee.Type != Queen || !g.Config.QueenShielded {
+		return 0
+	}
+	return len(g.GetBeesByType(Worker))
+}
+
+// beeKilled follows up the bees a single player attack has just killed, however they died: each kill
+// is recorded, morale and lifesteal reward it and it may drop an item, then a dead Queen brings the hive down
+func (g *Game) beeKilled(damage int, fallen ...*Bee) {
+	queenKilled := false
+	for _, bee := range fallen {
+		g.emit(GameEvent{Action: EventBeeKilled, Actor: "player", Target: bee.Type.String(), Damage: damage, BeeID: bee.ID})
+		g.adjustMorale(MoraleGainPerKill)
+
+		// Lifesteal rewards the kill with a little health
+		if healed := g.healPlayer(g.Config.Lifesteal); healed > 0 {
+			fmt.Fprintf(g.out(), "🩹 You absorb %d HP from the kill!\n", healed)
+		}
+
+		// The fallen bee might leave something useful behind
+		g.rollItemDrop()
+		queenKilled = queenKilled || bee.Type == Queen
+	}
+
+	// Special rule: killing the Queen kills everyone, however many Queens fell at once
+	if queenKilled {
+		g.queenWipe()
+	}
+}
+
+// splashTargets picks up to SplashTargets living bees of the target's type nearest it in ID order,
+// alternating after and before it, or nothing when SplashDamage is off
+func (g *Game) splashTargets(target *Bee) []*Bee {
+	if g.Config.SplashDamage <= 0 {
+		return nil
+	}
+
+	sameType := g.GetBeesByType(target.Type)
+	at := slices.Index(sameType, target)
+	if at < 0 {
+		return nil
+	}
+	var targets []*Bee
+	for d := 1; len(targets) < SplashTargets && (at-d >= 0 || at+d < len(sameType)); d++ {
+		if at+d < len(sameType) {
+			targets = append(targets, sameType[at+d])
+		}
+		if len(targets) < SplashTargets && at-d >= 0 {
+			targets = append(targets, sameType[at-d])
+		}
+	}
+	return targets
+}
+
+// splash deals SplashDamage to each bee still alive among the targets, sparing a shielded Queen.
+// A splash kill counts like any other, so it is rewarded and a Queen's death wipes the hive.
+func (g *Game) splash(targets []*Bee) {
+	damage := g.Config.SplashDamage
+	if g.Config.Berserker {
+		damage = berserkerScale(damage)
+	}
+	for _, bee := range targets {
+		if g.shieldingWorkers(bee) > 0 {
+			continue
+		}
+		g.mu.Lock()
+		if !bee.IsAlive() {
+			g.mu.Unlock()
+			continue
+		}
+		g.damageBeeUnsafe(bee, damage)
+		hp := bee.HP
+		g.mu.Unlock()
+
+		if hp > 0 {
+			fmt.Fprintf(g.out(), "💥 The splash hits %s for %d damage (%d HP left).\n", bee.describe("a"), damage, hp)
+			g.emit(GameEvent{Action: EventPlayerHit, Actor: "player", Target: bee.Type.String(), Damage: damage, TargetHP: hp, BeeID: bee.ID})
+			continue
+		}
+		fmt.Fprintf(g.out(), "💥 The splash kills %s!\n", bee.describe("a"))
+		g.beeKilled(damage, bee)
+	}
+}
+
+// SpecialReady reports whether the rage meter is full and 'special' can be used
+func (g *Game) SpecialReady() bool {
+	rage, rageMax := g.rageMeter()
+	return rageMax > 0 && rage >= rageMax
+}
+
+// rageMeter safely reads the current and maximum rage
+func (g *Game) rageMeter() (int, int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Rage, g.Config.RageMeterMax
+}
+
+// fillRage adds damage taken to the rage meter, announcing when it becomes full
+func (g *Game) fillRage(damage int) {
+	g.mu.Lock()
+	rageMax := g.Config.RageMeterMax
+	wasFull := g.Rage >= rageMax
+	g.Rage += damage
+	if g.Rage > rageMax {
+		g.Rage = rageMax
+	}
+	nowFull := g.Rage >= rageMax
+	g.mu.Unlock()
+
+	if rageMax > 0 && nowFull && !wasFull {
+		fmt.Fprintln(g.out(), "😤 Your rage meter is full! Type 'special' to unleash it on the whole hive.")
+	}
+}
+
+// SpecialAttack empties a full rage meter to damage every living bee at once
+func (g *Game) SpecialAttack() {
+	if !g.SpecialReady() {
+		fmt.Fprintln(g.out(), "Your rage meter isn't full yet!")
+		return
+	}
+
+	g.mu.Lock()
+	g.Rage = 0
+	g.mu.Unlock()
+
+	damage := g.Config.SpecialDamage
+	if g.Config.Berserker {
+		damage = berserkerScale(damage)
+	}
+	fmt.Fprintf(g.out(), "💥 RAGE UNLEASHED! You strike every bee in the hive for %d damage!\n", damage)
+
+	// A shielded Queen is spared while her Workers live, judged before the blast fells any of them
+	aliveBees := slices.DeleteFunc(g.GetAliveBees(), func(bee *Bee) bool {
+		if workers := g.shieldingWorkers(bee); workers > 0 {
+			fmt.Fprintf(g.out(), "🛡️ %s is shielded while her %d Workers live - the blast can't touch her!\n", bee.describe("The"), workers)
+			return true
+		}
+		return false
+	})
+
+	var fallen []*Bee
+	g.mu.Lock()
+	for _, bee := range aliveBees {
+		g.damageBeeUnsafe(bee, damage)
+		if !bee.IsAlive() {
+			fallen = append(fallen, bee)
+		}
+	}
+	g.mu.Unlock()
+
+	for _, bee := range aliveBees {
+		if bee.IsAlive() {
+			g.emit(GameEvent{Action: EventPlayerHit, Actor: "player", Target: bee.Type.String(), Damage: damage, TargetHP: bee.HP, BeeID: bee.ID})
+		}
+	}
+	fmt.Fprintf(g.out(), "The blast hit %d bees and killed %d of them.\n", len(aliveBees), len(fallen))
+	g.beeKilled(damage, fallen...)
+}
+
+// parseCleave checks a 'cleave <type>' command can be used and returns the bee type it targets
+func (g *Game) parseCleave(command string) (BeeType, error) {
+	fields := strings.Fields(command)
+	if len(fields) != 2 || fields[0] != "cleave" {
+		return 0, &CommandError{Command: command, Kind: CommandInvalid, Err: fmt.Errorf("usage: cleave <queen|worker|drone>")}
+	}
+
+	var beeType BeeType
+	if err := beeType.UnmarshalText([]byte(strings.TrimSuffix(fields[1], "s"))); err != nil {
+		return 0, &CommandError{Command: command, Kind: CommandInvalid, Err: err}
+	}
+
+	g.mu.RLock()
+	cleavesLeft := g.CleavesLeft
+	g.mu.RUnlock()
+	if cleavesLeft <= 0 {
+		return 0, &CommandError{Command: command, Kind: CommandUnavailable, Err: ErrNoCleaves}
+	}
+	if len(g.GetBeesByType(beeType)) == 0 {
+		return 0, &CommandError{Command: command, Kind: CommandNoTargets, Err: fmt.Errorf("%w: %s", ErrNoBeesOfType, beeType)}
+	}
+	return beeType, nil
+}
+
+// Cleave spends a charge to hit every living bee of one type once
+func (g *Game) Cleave(beeType BeeType) {
+	g.mu.Lock()
+	if g.CleavesLeft <= 0 {
+		g.mu.Unlock()
+		fmt.Fprintln(g.out(), "You have no cleaves left!")
+		return
+	}
+	g.CleavesLeft--
+	cleavesLeft := g.CleavesLeft
+	g.mu.Unlock()
+
+	targets := g.GetBeesByType(beeType)
+	if len(targets) == 0 {
+		fmt.Fprintf(g.out(), "🪓 Your cleave sweeps through empty air - there are no %s bees left! (%d cleaves left)\n", beeType, cleavesLeft)
+		return
+	}
+
+	// Like any other blow, a cleave glances off a shielded Queen while her Workers live
+	if workers := g.shieldingWorkers(targets[0]); workers > 0 {
+		fmt.Fprintf(g.out(), "🛡️ Your cleave glances off! The Queen is shielded while her %d Workers live - clear them first! (%d cleaves left)\n", workers, cleavesLeft)
+		return
+	}
+
+	damage := g.getDamageDealtTo(beeType)
+	fmt.Fprintf(g.out(), "🪓 CLEAVE! You sweep through %d %s bees for %d damage each! (%d cleaves left)\n", len(targets), beeType, damage, cleavesLeft)
+
+	var fallen []*Bee
+	g.mu.Lock()
+	for _, bee := range targets {
+		g.damageBeeUnsafe(bee, damage)
+		if !bee.IsAlive() {
+			fallen = append(fallen, bee)
+		}
+	}
+	g.mu.Unlock()
+
+	for _, bee := range targets {
+		if bee.IsAlive() {
+			g.emit(GameEvent{Action: EventPlayerHit, Actor: "player", Target: beeType.String(), Damage: damage, TargetHP: bee.HP, BeeID: bee.ID})
+		}
+	}
+	if len(fallen) > 0 {
+		fmt.Fprintf(g.out(), "You killed %d of them!\n", len(fallen))
+	}
+	g.beeKilled(damage, fallen...)
+}
+
+// EffectiveMissChance is the player's miss chance after low morale has shaken their aim
+func (g *Game) EffectiveMissChance() float64 {
+	missChance := g.Config.PlayerMissChance
+	if !g.Config.Morale {
+		return missChance
+	}
+
+	g.mu.RLock()
+	morale := g.Morale
+	g.mu.RUnlock()
+
+	missChance += MoraleMaxMissPenalty * float64(MoraleMax-morale) / MoraleMax
+	return min(missChance, 1.0)
+}
+
+// HiveHealthBar draws the hive's remaining HP, out of every bee's max HP, as an ASCII bar
+// of the given width followed by the percentage, e.g. "[#####-----] 50%"
+func (g *Game) HiveHealthBar(width int) string {
+	g.mu.RLock()
+	remaining, total := 0, 0
+	for _, beeList := range g.Hive {
+		for _, bee := range beeList {
+			total += bee.MaxHP
+			if bee.IsAlive() {
+				remaining += bee.HP
+			}
+		}
+	}
+	g.mu.RUnlock()
+
+	if width < 1 {
+		width = 1
+	}
+	filled, percent := 0, 0
+	if total > 0 {
+		filled = int(math.Round(float64(remaining*width) / float64(total)))
+		percent = int(math.Round(float64(remaining*100) / float64(total)))
+	}
+	return fmt.Sprintf("[%s%s] %d%%", strings.Repeat("#", filled), strings.Repeat("-", width-filled), percent)
+}
+
+// ExportGrid returns the hive as rows of glyphs, one type after another in creation order
+// with at most HiveMapWidth bees per row: Q, W or D for a healthy bee, lowercase once wounded
+// and a space once dead. Rows are as long as the bees they hold, so the last row of a type may be short
+func (g *Game) ExportGrid() [][]rune {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var grid [][]rune
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		beeList := g.Hive[beeType]
+		for start := 0; start < len(beeList); start += HiveMapWidth {
+			row := beeList[start:min(start+HiveMapWidth, len(beeList))]
+			glyphs := make([]rune, len(row))
+			for i, bee := range row {
+				glyphs[i] = beeGlyph(bee)
+			}
+			grid = append(grid, glyphs)
+		}
+	}
+	return grid
+}
+
+// beeGlyph is the hive map glyph for a bee's type and health
+func beeGlyph(bee *Bee) rune {
+	glyph := rune(bee.Type.String()[0])
+	switch {
+	case !bee.IsAlive():
+		return ' '
+	case bee.HP < bee.MaxHP:
+		return unicode.ToLower(glyph)
+	default:
+		return glyph
+	}
+}
+
+// RenderHiveMap draws ExportGrid as text, one "|Q W W|" line per row
+func (g *Game) RenderHiveMap() string {
+	var sb strings.Builder
+	for _, row := range g.ExportGrid() {
+		glyphs := make([]string, len(row))
+		for i, glyph := range row {
+			glyphs[i] = string(glyph)
+		}
+		fmt.Fprintf(&sb, "|%s|\n", strings.Join(glyphs, " "))
+	}
+	return sb.String()
+}
+
+// EffectiveBeesMissChance is the chance each bee misses its sting this turn, lowered once the hive enrages
+// and, with AdaptiveAggression, shifted by how healthy the player is
+func (g *Game) EffectiveBeesMissChance() float64 {
+	g.mu.RLock()
+	enraged := g.Enraged
+	playerHP, playerMaxHP := g.Player.HP, g.Player.MaxHP
+	g.mu.RUnlock()
+
+	chance := g.Config.BeesMissChance
+	if enraged {
+		chance *= EnrageMissMultiplier
+	}
+	if g.Config.AdaptiveAggression && playerMaxHP > 0 {
+		// Full health sharpens the bees by the whole swing, near death blunts them by as much
+		hpFraction := float64(playerHP) / float64(playerMaxHP)
+		chance += AdaptiveAggressionSwing * (1 - 2*hpFraction)
+	}
+	return max(0, min(chance, 1))
+}
+
+// PrintOdds shows the current hit and miss chances on both sides without using a turn
+func (g *Game) PrintOdds() {
+	playerMiss := g.EffectiveMissChance()
+	beesMiss := g.EffectiveBeesMissChance()
+	aliveBees := len(g.GetAliveBees())
+
+	fmt.Fprintln(g.out(), "\n=== Current Odds ===")
+	fmt.Fprintf(g.out(), "Your miss chance: %.1f%% (hit chance %.1f%%)\n", playerMiss*100, (1-playerMiss)*100)
+	fmt.Fprintf(g.out(), "Each bee's miss chance: %.1f%%\n", beesMiss*100)
+	fmt.Fprintf(g.out(), "Chance at least one of the %d bees stings you this turn: %.1f%%\n",
+		aliveBees, (1-math.Pow(beesMiss, float64(aliveBees)))*100)
+}
+
+// adjustMorale shifts the player's morale within 0 and MoraleMax when the Morale option is set
+func (g *Game) adjustMorale(delta int) {
+	if !g.Config.Morale {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Morale = max(0, min(g.Morale+delta, MoraleMax))
+}
+
+// selectTarget picks which bee the player hits using the TargetSelector, defaulting to
+// uniform random (weighted by type when TargetWeights is configured)
+func (g *Game) selectTarget(aliveBees []*Bee) *Bee {
+	selector := g.TargetSelector
+	if selector == nil {
+		selector = WeightedSelector{Weights: g.Config.TargetWeights}
+	}
+	return selector.Select(aliveBees, g.rng)
+}
+
+// rollItemDrop gives the player a shield with the configured drop chance
+func (g *Game) rollItemDrop() {
+	if g.Config.ItemDropChance <= 0 || g.rng.Float64() >= g.Config.ItemDropChance {
+		return
+	}
+
+	g.mu.Lock()
+	alreadyShielded := g.HasShield
+	g.HasShield = true
+	g.mu.Unlock()
+
+	if alreadyShielded {
+		fmt.Fprintln(g.out(), "🛡️ You found another shield, but you can only carry one.")
+		return
+	}
+	fmt.Fprintln(g.out(), "🛡️ You picked up a shield! It will block the next sting.")
+}
+
+// weakestBee returns the bee with the least HP, breaking ties by the lowest ID
+func weakestBee(bees []*Bee) *Bee {
+	var weakest *Bee
+	for _, bee := range bees {
+		if weakest == nil || bee.HP < weakest.HP || (bee.HP == weakest.HP && bee.ID < weakest.ID) {
+			weakest = bee
+		}
+	}
+	return weakest
+}
+
+// queenWipe applies the Queen-death rule, sparing any bee types listed in the config
+func (g *Game) queenWipe() {
+	g.ringBell()
+
+	if g.Config.DisableQueenWipe {
+		fmt.Fprintln(g.out(), "👑 QUEEN BEE ELIMINATED! The hive is leaderless, but the remaining bees fight on!")
+		return
+	}
+
+	spared := g.Config.QueenWipeSpares
+	if len(spared) == 0 {
+		fmt.Fprintln(g.out(), "🔥 QUEEN BEE ELIMINATED! All remaining bees flee in terror! 🔥")
+	} else {
+		names := make([]string, len(spared))
+		for i, beeType := range spared {
+			names[i] = beeType.String()
+		}
+		fmt.Fprintf(g.out(), "🔥 QUEEN BEE ELIMINATED! The hive collapses, but the %s bees fight on! 🔥\n", strings.Join(names, " and "))
+	}
+	g.KillAllBeesExcept(spared...)
+	g.emit(GameEvent{Action: EventQueenWipe, Actor: "player", Target: "hive"})
+}
+
+// BeeTurn makes the bees attack back using concurrent decision making
+func (g *Game) BeeTurn() {
+	currentTurn := g.currentTurn()
+
+	fmt.Fprintf(g.out(), "\n--- Turn %d: Bees Turn ---\n", currentTurn)
+
+	aliveBees := g.GetAliveBees()
+	if len(aliveBees) == 0 {
+		return
+	}
+
+	// Occasionally the whole drone swarm goes berserk for this turn only
+	berserk := g.Config.BerserkChance > 0 && g.beeRng.Float64() < g.Config.BerserkChance
+	if berserk && len(g.GetBeesByType(Drone)) > 0 {
+		fmt.Fprintln(g.out(), "😡 The drones go berserk! Drone stings deal double damage this turn!")
+	}
+
+	// Past the sudden death threshold the hive gets more desperate every turn
+	if doublings := g.suddenDeathDoublings(currentTurn); doublings > 0 {
+		fmt.Fprintf(g.out(), "☠️ SUDDEN DEATH%s Bee stings deal x%d damage!\n", strings.Repeat("!", doublings), 1<<doublings)
+	}
+
+	g.checkEnrage()
+
+	defer g.levelUpBees(aliveBees)
+
+	attackers := g.healHive(aliveBees)
+	switch {
+	case len(attackers) == 0:
+		// Only healers are left, so nothing stings this turn
+	case g.Config.TwoPlayer && g.interactive && !g.AutoMode:
+		// With nobody at the keyboard (auto mode, Step or the server) the AI plays the hive instead
+		g.humanBeeTurn(attackers, berserk)
+	default:
+		g.aiBeeTurn(attackers, berserk)
+	}
+
+	g.applySwarmPressure()
+	g.rollFlee()
+	g.rollReinforcement()
+	g.applyMercyRule()
+}
+
+// healHive has every healer Drone mend the most wounded living bee instead of attacking,
+// and returns the bees left to attack
+func (g *Game) healHive(aliveBees []*Bee) []*Bee {
+	attackers := make([]*Bee, 0, len(aliveBees))
+	for _, bee := range aliveBees {
+		if !bee.Healer {
+			attackers = append(attackers, bee)
+			continue
+		}
+
+		g.mu.Lock()
+		patient := g.mostWoundedBeeUnsafe()
+		var healed, patientHP int
+		if patient != nil {
+			healed = min(HealerDroneHealing, patient.MaxHP-patient.HP)
+			patient.HP += healed
+			patientHP = patient.HP
+		}
+		g.mu.Unlock()
+
+		if patient == nil {
+			continue
+		}
+		fmt.Fprintf(g.out(), "💚 %s (#%d) tends to %s (#%d), healing %d HP (%d/%d).\n",
+			bee.describe("The healer"), bee.ID, patient.describe("the"), patient.ID, healed, patientHP, patient.MaxHP)
+		g.emit(GameEvent{Action: EventBeeHealed, Actor: bee.Type.String(), Target: patient.Type.String(), BeeID: patient.ID, TargetHP: patientHP})
+	}
+	return attackers
+}
+
+// mostWoundedBeeUnsafe finds the living bee missing the most HP, the lowest ID on a tie,
+// or nil when no bee is hurt (caller must hold the mutex)
+func (g *Game) mostWoundedBeeUnsafe() *Bee {
+	var patient *Bee
+	for _, bee := range g.getAliveBeesUnsafe() {
+		missing := bee.MaxHP - bee.HP
+		if missing <= 0 {
+			continue
+		}
+		if patient == nil || missing > patient.MaxHP-patient.HP || (missing == patient.MaxHP-patient.HP && bee.ID < patient.ID) {
+			patient = bee
+		}
+	}
+	return patient
+}
+
+// aiBeeTurn has every bee decide whether to attack and stings the player with one of the hits
+func (g *Game) aiBeeTurn(aliveBees []*Bee, berserk bool) {
+	hits, misses, profile := g.collectBeeDecisions(aliveBees)
+
+	// Display thinking time (for demonstration)
+	fmt.Fprintf(g.out(), "🧠 Bees consulted for %v total...\n", profile.Serial)
+	if g.Config.ProfileTurns {
+		fmt.Fprintf(g.out(), "⏱️ %s\n", profile)
+	}
+
+	// Execute attack based on decisions
+	if len(hits) > 0 {
+		// Up to MaxBeeHitsPerTurn of the hits land, picked at random without repeats
+		landed := min(max(g.Config.MaxBeeHitsPerTurn, 1), len(hits))
+		if landed > 1 {
+			fmt.Fprintf(g.out(), "🐝 %d stings land this turn!\n", landed)
+		}
+		downs := g.timesDowned()
+		for i := 0; i < landed; i++ {
+			j := i + g.beeRng.Intn(len(hits)-i)
+			hits[i], hits[j] = hits[j], hits[i]
+			// Once the player falls, even if a revive or spare life puts them back up, the rest hold off
+			if g.timesDowned() != downs {
+				break
+			}
+			if g.blockSting(hits[i].Bee) {
+				continue
+			}
+			g.stingPlayer(hits[i].Bee, berserk)
+			g.barbedStingDeath(hits[i].Bee)
+		}
+	} else if len(misses) > 0 {
+		// All bees missed - show a random miss
+		chosenMiss := misses[g.beeRng.Intn(len(misses))]
+		fmt.Fprintf(g.out(), "Buzz! That was close! The %s Bee just missed you!\n",
+			chosenMiss.Bee.Type.String())
+		g.emit(GameEvent{Action: EventBeeMiss, Actor: chosenMiss.Bee.Type.String(), Target: "player", BeeID: chosenMiss.Bee.ID})
+		g.adjustMorale(-MoraleLossPerBeeMiss)
+	}
+}
+
+// checkEnrage enrages the surviving bees, once per game, when the living fraction of the hive drops below EnrageBelow
+func (g *Game) checkEnrage() {
+	if g.Config.EnrageBelow <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	if g.Enraged {
+		g.mu.Unlock()
+		return
+	}
+	total := 0
+	var survivors []*Bee
+	for _, beeList := range g.Hive {
+		total += len(beeList)
+		for _, bee := range beeList {
+			if bee.IsAlive() {
+				survivors = append(survivors, bee)
+			}
+		}
+	}
+	if total == 0 || float64(len(survivors))/float64(total) >= g.Config.EnrageBelow {
+		g.mu.Unlock()
+		return
+	}
+	g.Enraged = true
+	for _, bee := range survivors {
+		bee.Damage += EnrageDamageBonus
+	}
+	g.mu.Unlock()
+
+	fmt.Fprintf(g.out(), "🔥 The hive is ENRAGED! Its last %d bees fight harder and miss less often!\n", len(survivors))
+	g.emit(GameEvent{Action: EventEnrage, Actor: "hive", Target: "player"})
+}
+
+// humanBeeTurn lets the second player choose which bee stings, or hold the hive back.
+// The chosen bee still rolls the bees' miss chance, just as the player's attacks can miss.
+func (g *Game) humanBeeTurn(aliveBees []*Bee, berserk bool) {
+	bee, ok := g.promptBeeChoice(aliveBees)
+	if !ok {
+		fmt.Fprintln(g.out(), "🐝 The hive holds back this turn.")
+		return
+	}
+
+	if g.beeRng.Float64() < g.EffectiveBeesMissChance() {
+		fmt.Fprintf(g.out(), "Buzz! That was close! The %s Bee just missed you!\n", bee.Type.String())
+		g.emit(GameEvent{Action: EventBeeMiss, Actor: bee.Type.String(), Target: "player", BeeID: bee.ID})
+		g.adjustMorale(-MoraleLossPerBeeMiss)
+		return
+	}
+	if g.blockSting(bee) {
+		return
+	}
+	g.stingPlayer(bee, berserk)
+	g.barbedStingDeath(bee)
+}
+
+// blockSting telegraphs an incoming sting and gives the player BlockWindow milliseconds to
+// type 'block', reporting whether they made it. Anything else, or too slow a reply, lets the sting land.
+// Only a person playing through PlayGame is asked; Step and the server never wait on a reply.
+func (g *Game) blockSting(bee *Bee) bool {
+	if g.Config.BlockWindow <= 0 || g.AutoMode || !g.interactive {
+		return false
+	}
+
+	fmt.Fprintf(g.out(), "⚠️ %s rears back to sting! Type 'block' within %dms: ", bee.describe("A"), g.Config.BlockWindow)
+	line, err := g.readLineWithin(time.Duration(g.Config.BlockWindow) * time.Millisecond)
+	if errors.Is(err, errInputTimeout) {
+		fmt.Fprintln(g.out(), "\n⏰ Too slow!")
+		return false
+	}
+	if err != nil || strings.TrimSpace(strings.ToLower(line)) != "block" {
+		return false
+	}
+
+	fmt.Fprintf(g.out(), "🛡️ You block the sting from %s!\n", bee.describe("the"))
+	g.emit(GameEvent{Action: EventStingBlocked, Actor: "player", Target: bee.Type.String(), BeeID: bee.ID})
+	return true
+}
+
+// barbedStingDeath kills a Worker that has just stung the player when WorkersDieOnSting is set,
+// just as a real worker bee loses its barbed stinger
+func (g *Game) barbedStingDeath(bee *Bee) {
+	if !g.Config.WorkersDieOnSting || bee.Type != Worker {
+		return
+	}
+
+	g.mu.Lock()
+	bee.HP = 0
+	g.getAliveBeesUnsafe()
+	g.mu.Unlock()
+
+	fmt.Fprintf(g.out(), "🪦 %s leaves its stinger behind and dies.\n", bee.describe("The"))
+	g.emit(GameEvent{Action: EventBarbedSting, Actor: bee.Type.String(), Target: "player", BeeID: bee.ID})
+}
+
+// promptBeeChoice shows the living bees and asks the hive player for the ID of the one to sting with.
+// It reports false when the hive passes or input runs out.
+func (g *Game) promptBeeChoice(aliveBees []*Bee) (*Bee, bool) {
+	byID := make(map[int]*Bee, len(aliveBees))
+	ids := make(map[BeeType][]string)
+	for _, bee := range aliveBees {
+		byID[bee.ID] = bee
+		ids[bee.Type] = append(ids[bee.Type], strconv.Itoa(bee.ID))
+	}
+
+	fmt.Fprintln(g.out(), "🐝 Hive player, your bees:")
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		if len(ids[beeType]) > 0 {
+			fmt.Fprintf(g.out(), "  %ss: %s\n", beeType, strings.Join(ids[beeType], " "))
+		}
+	}
+
+	for {
+		fmt.Fprint(g.out(), "Choose a bee ID to sting with (or 'pass'): ")
+		line, err := g.readLine()
+		if errors.Is(err, errInputTimeout) {
+			fmt.Fprintln(g.out(), "\n⏰ The hive player took too long and passes.")
+			return nil, false
+		}
+		if err != nil {
+			return nil, false
+		}
+		input := strings.TrimSpace(strings.ToLower(line))
+		if input == "pass" {
+			return nil, false
+		}
+		if id, err := strconv.Atoi(input); err == nil {
+			if bee, ok := byID[id]; ok {
+				return bee, true
+			}
+		}
+		fmt.Fprintf(g.out(), "No living bee has ID %q.\n", input)
+	}
+}
+
+// rollReinforcement gives the hive a chance to spawn a fresh Drone, up to the configured cap
+func (g *Game) rollReinforcement() {
+	if g.Config.ReinforcementChance <= 0 || g.beeRng.Float64() >= g.Config.ReinforcementChance {
+		return
+	}
+
+	g.mu.Lock()
+	capped := g.Config.MaxReinforcements > 0 && g.Reinforcements >= g.Config.MaxReinforcements
+	over := !g.Player.IsAlive() || g.countAliveBeesUnsafe() == 0
+	if capped || over {
+		g.mu.Unlock()
+		return
+	}
+	drone := g.addBee(Drone)
+	g.Reinforcements++
+	g.mu.Unlock()
+
+	if drone.Name != "" {
+		fmt.Fprintf(g.out(), "📯 Reinforcements! %s (#%d) joins the hive.\n", drone.describe("a"), drone.ID)
+	} else {
+		fmt.Fprintf(g.out(), "📯 Reinforcements! A fresh Drone (#%d) joins the hive.\n", drone.ID)
+	}
+	g.emit(GameEvent{Action: EventReinforcement, Actor: "hive", Target: drone.Type.String(), TargetHP: drone.HP, BeeID: drone.ID})
+}
+
+// rollFlee gives each badly wounded Worker and Drone a chance to flee the fight.
+// The Queen never abandons her hive, and the last bee standing always stays to fight.
+func (g *Game) rollFlee() {
+	if !g.Config.AllowFlee || g.Config.FleeChance <= 0 {
+		return
+	}
+
+	var fled []*Bee
+	g.mu.Lock()
+	if !g.Player.IsAlive() {
+		g.mu.Unlock()
+		return
+	}
+	alive := g.countAliveBeesUnsafe()
+	for _, beeType := range []BeeType{Worker, Drone} {
+		staying := g.Hive[beeType][:0]
+		for _, bee := range g.Hive[beeType] {
+			wounded := bee.IsAlive() && float64(bee.HP) < float64(bee.MaxHP)*FleeBelowFraction
+			if wounded && alive > 1 && g.beeRng.Float64() < g.Config.FleeChance {
+				g.Fled = append(g.Fled, FledBee{Bee: bee, ReturnTurn: g.Turns + g.Config.FleeReturnTurns})
+				fled = append(fled, bee)
+				alive--
+				continue
+			}
+			staying = append(staying, bee)
+		}
+		g.Hive[beeType] = staying
+	}
+	if len(fled) > 0 {
+		g.rebuildAliveBeesUnsafe()
+	}
+	g.mu.Unlock()
 
-	// Add the Queen Bees
-	for i := 0; i < g.Config.QueenCount; i++ {
-		bee := NewBee(Queen)
-		g.Hive[Queen] = append(g.Hive[Queen], bee)
-		g.AliveBees = append(g.AliveBees, bee)
+	for _, bee := range fled {
+		fmt.Fprintf(g.out(), "🏃 %s (#%d) flees the fight with %d HP!\n", bee.describe("The"), bee.ID, bee.HP)
+		g.emit(GameEvent{Action: EventBeeFled, Actor: "hive", Target: bee.Type.String(), TargetHP: bee.HP, BeeID: bee.ID})
 	}
+}
 
-	// Add the Worker Bees
-	for i := 0; i < g.Config.WorkerCount; i++ {
-		bee := NewBee(Worker)
-		g.Hive[Worker] = append(g.Hive[Worker], bee)
-		g.AliveBees = append(g.AliveBees, bee)
+// returnFledBees puts fled bees whose return turn has come back into the hive
+func (g *Game) returnFledBees() {
+	var returned []*Bee
+	g.mu.Lock()
+	waiting := g.Fled[:0]
+	for _, fled := range g.Fled {
+		if g.Config.FleeReturnTurns <= 0 || g.Turns < fled.ReturnTurn {
+			waiting = append(waiting, fled)
+			continue
+		}
+		g.Hive[fled.Bee.Type] = append(g.Hive[fled.Bee.Type], fled.Bee)
+		returned = append(returned, fled.Bee)
+	}
+	g.Fled = waiting
+	if len(returned) > 0 {
+		g.rebuildAliveBeesUnsafe()
 	}
+	g.mu.Unlock()
 
-	// Add the Drone Bees
-	for i := 0; i < g.Config.DroneCount; i++ {
-		bee := NewBee(Drone)
-		g.Hive[Drone] = append(g.Hive[Drone], bee)
-		g.AliveBees = append(g.AliveBees, bee)
+	for _, bee := range returned {
+		fmt.Fprintf(g.out(), "↩️ %s (#%d) flies back into the fight with %d HP!\n", bee.describe("The"), bee.ID, bee.HP)
+		g.emit(GameEvent{Action: EventBeeReturned, Actor: "hive", Target: bee.Type.String(), TargetHP: bee.HP, BeeID: bee.ID})
 	}
 }
 
-// GetAliveBees gives you all the bees that are still alive
-func (g *Game) GetAliveBees() []*Bee {
+// stingPlayer resolves a landed sting from the given bee, after shields and armor have their say
+func (g *Game) stingPlayer(bee *Bee, berserk bool) {
+	fmt.Fprintf(g.out(), "Sting! You just got stung by %s!\n", bee.describe("a"))
+
+	// A shield soaks up the whole sting and breaks
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	shielded := g.HasShield
+	g.HasShield = false
+	g.mu.Unlock()
+	if shielded {
+		fmt.Fprintln(g.out(), "🛡️ Your shield absorbs the sting and shatters!")
+		return
+	}
+
+	damage := g.beeAttackDamage(bee, berserk)
+	if damage == 0 {
+		fmt.Fprintln(g.out(), "🛡️ Your armor completely absorbed the sting!")
+		return
+	}
 
-	return g.getAliveBeesUnsafe()
+	playerHP, playerAlive := g.damagePlayer(damage)
+	g.mu.Lock()
+	g.damageByBee[bee.ID] += damage
+	g.mu.Unlock()
+	fmt.Fprintf(g.out(), "You took %d damage and now have %d HP remaining.\n", damage, playerHP)
+	g.emit(GameEvent{Action: EventBeeSting, Actor: bee.Type.String(), Target: "player", Damage: damage, TargetHP: playerHP, BeeID: bee.ID})
+	g.adjustMorale(-MoraleLossPerSting)
+
+	if !playerAlive {
+		g.playerFell(bee.Type.String())
+	}
 }
 
-// getAliveBeesUnsafe is an internal helper that assumes the caller holds the mutex
-func (g *Game) getAliveBeesUnsafe() []*Bee {
-	// Rebuild the alive list by filtering out dead bees
-	aliveBees := make([]*Bee, 0, len(g.AliveBees))
-	for _, bee := range g.AliveBees {
-		if bee.IsAlive() {
-			aliveBees = append(aliveBees, bee)
-		}
+// playerFell settles a blow that left the player at 0 HP: a revive is tried first, then a spare
+// life, and only once both are spent is the player announced dead. Every death goes through here.
+func (g *Game) playerFell(killer string) {
+	if g.tryRevive() || g.respawn() {
+		return
 	}
-	g.AliveBees = aliveBees // Update the cached list
-	return aliveBees
+	g.announcePlayerDeath(killer)
 }
 
-// GetBeesByType finds all living bees of a particular type (O(1) map access to type group)
-func (g *Game) GetBeesByType(beeType BeeType) []*Bee {
-	g.mu.RLock()
-	defer g.mu.RUnlock()
+// tryRevive gives a fatally stung player a one-time ReviveChance to come back with ReviveHP
+func (g *Game) tryRevive() bool {
+	if g.Config.ReviveChance <= 0 {
+		return false
+	}
 
-	var bees []*Bee
-	for _, bee := range g.Hive[beeType] {
-		if bee.IsAlive() {
-			bees = append(bees, bee)
-		}
+	g.mu.Lock()
+	if g.Revived || g.rng.Float64() >= g.Config.ReviveChance {
+		g.mu.Unlock()
+		return false
 	}
-	return bees
+	g.Revived = true
+	g.Player.HP = max(1, min(g.Config.ReviveHP, g.Player.MaxHP))
+	playerHP := g.Player.HP
+	g.mu.Unlock()
+
+	fmt.Fprintf(g.out(), "💫 You cling to life! Refusing to fall, you rise again with %d HP!\n", playerHP)
+	g.emit(GameEvent{Action: EventPlayerRevived, Actor: "player", Target: "player", TargetHP: playerHP})
+	return true
 }
 
-// IsGameOver checks if someone has won or lost the game
-func (g *Game) IsGameOver() bool {
+// timesDowned counts how often the player has fallen this game: every spent life and revive, plus
+// a death they haven't come back from
+func (g *Game) timesDowned() int {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	// Player is dead
+	downs := g.Config.lives() - g.LivesLeft
+	if g.Revived {
+		downs++
+	}
 	if !g.Player.IsAlive() {
-		return true
+		downs++
 	}
+	return downs
+}
 
-	// All bees are dead - use internal method to avoid double locking
-	aliveBees := g.getAliveBeesUnsafe()
-	return len(aliveBees) == 0
+// lives is how many lives the player starts with, at least one
+func (c GameConfig) lives() int {
+	return max(c.Lives, 1)
 }
 
-// KillAllBees wipes out the entire hive (happens when the Queen dies)
-func (g *Game) KillAllBees() {
+// respawn spends one of the player's spare lives to bring them back at full HP,
+// reporting false once they are on their last life
+func (g *Game) respawn() bool {
 	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	for _, beeList := range g.Hive {
-		for _, bee := range beeList {
-			if bee.IsAlive() {
-				bee.HP = 0
-			}
-		}
+	if g.LivesLeft <= 1 {
+		g.mu.Unlock()
+		return false
 	}
-	g.AliveBees = []*Bee{} // Clear the alive list
+	g.LivesLeft--
+	livesLeft := g.LivesLeft
+	g.Player.HP = g.Player.MaxHP
+	playerHP := g.Player.HP
+	g.mu.Unlock()
+
+	g.ringBell()
+	fmt.Fprintf(g.out(), "💀 You have been stung down... but you respawn with %d HP! (lives left: %d)\n", playerHP, livesLeft)
+	g.emit(GameEvent{Action: EventPlayerRespawned, Actor: "player", Target: "player", TargetHP: playerHP})
+	return true
 }
 
-// PrintGameStatus shows the current state of the battle
-func (g *Game) PrintGameStatus() {
-	g.mu.RLock()
+// damagePlayer safely applies damage to the player and notifies the stats monitor
+func (g *Game) damagePlayer(damage int) (int, bool) {
+	// Thread-safe player damage application
+	g.mu.Lock()
+	before := g.Player.HP
+	g.Player.TakeDamage(damage)
+	g.DamageTaken += before - g.Player.HP
 	playerHP := g.Player.HP
-	playerMaxHP := g.Player.MaxHP
-	turns := g.Turns
-	g.mu.RUnlock()
-
-	fmt.Printf("\n=== Game Status ===\n")
-	fmt.Printf("Player HP: %d/%d\n", playerHP, playerMaxHP)
+	playerAlive := g.Player.IsAlive()
+	g.mu.Unlock()
 
-	queens := g.GetBeesByType(Queen)
-	workers := g.GetBeesByType(Worker)
-	drones := g.GetBeesByType(Drone)
+	// Trigger damage event for stats monitoring
+	g.notifyDamage(damage)
 
-	fmt.Printf("Alive Bees:\n")
-	fmt.Printf("  Queens: %d\n", len(queens))
-	fmt.Printf("  Workers: %d\n", len(workers))
-	fmt.Printf("  Drones: %d\n", len(drones))
-	fmt.Printf("Turns: %d\n", turns)
-	fmt.Println("==================")
+	// Pain fuels the special attack
+	if playerAlive {
+		g.fillRage(damage)
+	}
+	return playerHP, playerAlive
 }
 
-// Start welcomes the player and shows them what's happening
-func (g *Game) Start() {
-	fmt.Println("Welcome to Bees in the Trap!")
-	fmt.Println("Your mission: Destroy the hive before the bees sting you to death!")
-	fmt.Println("Type 'hit' to attack the hive, or 'auto' to let the game run automatically.")
-	g.PrintGameStatus()
+// announcePlayerDeath reports the player's death and who dealt the final blow
+func (g *Game) announcePlayerDeath(killer string) {
+	g.ringBell()
+	fmt.Fprintln(g.out(), "💀 You have been stung to death! 💀")
+	g.emit(GameEvent{Action: EventPlayerDied, Actor: killer, Target: "player"})
 }
 
-// PlayGame keeps the game running until someone wins or loses
-func (g *Game) PlayGame() {
-	scanner := bufio.NewScanner(os.Stdin)
-
-	for !g.IsGameOver() {
-		if g.AutoMode {
-			// Let the computer play automatically
-			g.PlayerTurn("hit")
-			time.Sleep(time.Duration(g.Config.AutoModeDelay) * time.Millisecond) // Small pause so you can follow along
-		} else {
-			// Wait for the player to tell us what to do
-			fmt.Print("\nEnter command (hit/auto/quit): ")
-			if !scanner.Scan() {
-				break
-			}
-
-			input := strings.TrimSpace(strings.ToLower(scanner.Text()))
+// DefeatInevitable projects whether the hive will sting the player to death before they can clear it,
+// even if every player attack lands and only the hive's weakest sting hits them each turn.
+// Only a hive that can't miss makes that certain, so any chance of a bee missing means no, and neither
+// is it certain when Workers die as they sting, a second player may pass or the player can block.
+// Spare lives count as full health bars still to sting through.
+func (g *Game) DefeatInevitable() bool {
+	aliveBees := g.GetAliveBees()
+	g.mu.RLock()
+	playerHP, shielded := g.Player.HP, g.HasShield
+	spareHP := (g.LivesLeft - 1) * g.Player.MaxHP
+	canRevive := g.Config.ReviveChance > 0 && !g.Revived
+	g.mu.RUnlock()
+	// Regeneration could outlast the hive, and a revive might save the player, so the projection never gives up on either
+	if len(aliveBees) == 0 || playerHP <= 0 || g.Config.PassiveRegen > 0 || canRevive || g.EffectiveBeesMissChance() > 0 {
+		return false
+	}
+	// Stingers that die off, a human picking the hive's moves and blocked stings all break the one-sting-a-turn projection
+	if g.Config.WorkersDieOnSting || g.Config.TwoPlayer || g.Config.BlockWindow > 0 {
+		return false
+	}
+	playerHP += max(spareHP, 0)
 
-			switch input {
-			case "hit":
-				g.PlayerTurn(input)
-			case "auto":
-				fmt.Println("Switching to auto mode...")
-				g.AutoMode = true
-				continue
-			case "quit":
-				fmt.Println("Thanks for playing!")
-				return
-			default:
-				fmt.Println("Invalid command. Use 'hit', 'auto', or 'quit'.")
-				continue
-			}
+	weakestSting := -1
+	for _, bee := range aliveBees {
+		if bee.Healer {
+			continue // Healers never sting
 		}
-
-		// See if the game ended after the player's turn
-		if g.IsGameOver() {
-			break
+		if damage := g.beeAttackDamage(bee, false); weakestSting < 0 || damage < weakestSting {
+			weakestSting = damage
 		}
-
-		// Now it's the bees' turn to fight back
-		g.BeeTurn()
+	}
+	if weakestSting <= 0 {
+		return false
 	}
 
-	g.EndGame()
+	attacks := max(g.Config.AttacksPerTurn, 1)
+	turnsToClear := (g.HitsToClear() + attacks - 1) / attacks
+	stingsToDie := (playerHP + weakestSting - 1) / weakestSting
+	if shielded {
+		stingsToDie++
+	}
+	// The player strikes before each of the hive's stings, so clearing on turn N outlasts N-1 stings
+	return stingsToDie < turnsToClear
 }
 
-// PlayerTurn lets the player do something on their turn
-func (g *Game) PlayerTurn(command string) {
+// applyMercyRule ends a hopeless game once the player is badly hurt and DefeatInevitable agrees.
+// The projection already counted any spare lives, so they are forfeited rather than respawned.
+func (g *Game) applyMercyRule() {
+	if !g.Config.MercyRule {
+		return
+	}
+	playerHP, playerMaxHP := g.playerHealth()
+	if playerHP <= 0 || float64(playerHP) >= float64(playerMaxHP)*MercyRuleHPFraction || !g.DefeatInevitable() {
+		return
+	}
+
+	fmt.Fprintln(g.out(), "🏳️ Mercy rule: defeat is inevitable - the hive would finish you before you could clear it.")
 	g.mu.Lock()
-	g.Turns++
-	currentTurn := g.Turns
+	g.Player.HP = 0
+	g.LivesLeft = 1
 	g.mu.Unlock()
-
-	fmt.Printf("\n--- Turn %d: Player Turn ---\n", currentTurn)
-
-	if command == "hit" {
-		g.PlayerAttack()
-	}
+	g.announcePlayerDeath("hive")
 }
 
-// PlayerAttack makes the player swing at the hive
-func (g *Game) PlayerAttack() {
-	aliveBees := g.GetAliveBees()
-	if len(aliveBees) == 0 {
-		fmt.Println("No bees left to attack!")
+// applySwarmPressure chips away at the player based on how many bees are still buzzing around
+func (g *Game) applySwarmPressure() {
+	if !g.Config.SwarmPressure {
 		return
 	}
 
-	// Sometimes you miss completely
-	if g.rng.Float64() < g.Config.PlayerMissChance {
-		fmt.Println("Miss! You just missed the hive, better luck next time!")
+	g.mu.RLock()
+	playerAlive := g.Player.IsAlive()
+	damage := g.countAliveBeesUnsafe() / SwarmPressureBeesPerDamage
+	g.mu.RUnlock()
+
+	if !playerAlive || damage == 0 {
 		return
 	}
 
-	// Pick a random bee to hit
-	targetBee := aliveBees[g.rng.Intn(len(aliveBees))]
-
-	fmt.Printf("Direct Hit! You attacked a %s bee!\n", targetBee.Type.String())
-
-	// Hit the bee
-	targetBee.TakeDamage()
-
-	if !targetBee.IsAlive() {
-		fmt.Printf("You killed the %s bee! (%d damage dealt)\n", targetBee.Type.String(), g.getDamageDealtTo(targetBee.Type))
+	playerHP, playerAlive := g.damagePlayer(damage)
+	fmt.Fprintf(g.out(), "🐝 Swarm pressure! The buzzing hive wears you down for %d damage (%d HP left).\n", damage, playerHP)
+	g.emit(GameEvent{Action: EventSwarmPressure, Actor: "hive", Target: "player", Damage: damage, TargetHP: playerHP})
 
-		// Special rule: killing the Queen kills everyone
-		if targetBee.Type == Queen {
-			fmt.Println("🔥 QUEEN BEE ELIMINATED! All remaining bees flee in terror! 🔥")
-			g.KillAllBees()
-		}
-	} else {
-		fmt.Printf("The %s bee took %d damage and has %d HP remaining.\n", targetBee.Type.String(), g.getDamageDealtTo(targetBee.Type), targetBee.HP)
+	if !playerAlive {
+		g.playerFell("hive")
 	}
 }
 
-// BeeTurn makes the bees attack back using concurrent decision making
-func (g *Game) BeeTurn() {
-	g.mu.RLock()
-	currentTurn := g.Turns
-	g.mu.RUnlock()
+// levelUpBees rewards every bee that acted this turn with a level when leveling is enabled
+func (g *Game) levelUpBees(bees []*Bee) {
+	if !g.Config.BeeLeveling {
+		return
+	}
 
-	fmt.Printf("\n--- Turn %d: Bees Turn ---\n", currentTurn)
+	g.mu.Lock()
+	leveled := 0
+	for _, bee := range bees {
+		if bee.IsAlive() && bee.LevelUp() {
+			leveled++
+		}
+	}
+	g.mu.Unlock()
 
-	aliveBees := g.GetAliveBees()
-	if len(aliveBees) == 0 {
-		return
+	if leveled > 0 {
+		fmt.Fprintf(g.out(), "📈 %d surviving bees grow more dangerous!\n", leveled)
 	}
+}
+
+// collectBeeDecisions has every bee decide concurrently and splits the results into hits and misses,
+// profiling how long the decisions took together against one after another
+func (g *Game) collectBeeDecisions(aliveBees []*Bee) ([]BeeDecision, []BeeDecision, DecisionProfile) {
+	started := g.clock().Now()
 
 	// Channel to collect bee decisions
 	decisionChan := make(chan BeeDecision, len(aliveBees))
 	var wg sync.WaitGroup
 
-	// Each bee makes a decision concurrently
+	// Semaphore bounding how many bees think at once so huge hives don't spawn thousands of goroutines
+	maxConcurrent := g.Config.MaxConcurrentDecisions
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentDecisions
+	}
+	sem := make(chan struct{}, maxConcurrent)
+
+	// Each bee makes a decision concurrently, with its seed drawn up front in hive order
+	// so a seeded game makes the same decisions regardless of goroutine scheduling
 	for _, bee := range aliveBees {
+		seed := g.nextBeeSeed()
+		sem <- struct{}{} // Wait for a free slot before starting another goroutine
+
 		wg.Add(1)
-		go func(b *Bee) {
+		go func(b *Bee, seed int64) {
 			defer wg.Done()
-			decision := g.makeBeeDecision(b)
+			defer func() { <-sem }()
+			decision := g.makeBeeDecisionWithRand(b, rand.New(rand.NewSource(seed)))
 			decisionChan <- decision
-		}(bee)
+		}(bee, seed)
 	}
 
 	// Wait for all bees to make decisions
@@ -378,10 +3008,10 @@ func (g *Game) BeeTurn() {
 	// Collect all decisions
 	var hits []BeeDecision
 	var misses []BeeDecision
-	totalDecisionTime := time.Duration(0)
+	profile := DecisionProfile{Bees: len(aliveBees)}
 
 	for decision := range decisionChan {
-		totalDecisionTime += decision.DecisionTime
+		profile.Serial += decision.DecisionTime
 		if decision.WillHit {
 			hits = append(hits, decision)
 		} else {
@@ -389,50 +3019,31 @@ func (g *Game) BeeTurn() {
 		}
 	}
 
-	// Display thinking time (for demonstration)
-	fmt.Printf("🧠 Bees consulted for %v total...\n", totalDecisionTime)
-
-	// Execute attack based on decisions
-	if len(hits) > 0 {
-		// Random successful attack from the hits
-		chosenAttack := hits[g.rng.Intn(len(hits))]
-		fmt.Printf("Sting! You just got stung by a %s bee!\n", chosenAttack.Bee.Type.String())
-
-		damage := chosenAttack.Bee.Damage
-
-		// Thread-safe player damage application
-		g.mu.Lock()
-		g.Player.TakeDamage(damage)
-		playerHP := g.Player.HP
-		playerAlive := g.Player.IsAlive()
-		g.mu.Unlock()
-
-		fmt.Printf("You took %d damage and now have %d HP remaining.\n", damage, playerHP)
+	// Decisions arrive in goroutine completion order; sort them so a seeded game picks the same bees every run
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Bee.ID < hits[j].Bee.ID })
+	sort.Slice(misses, func(i, j int) bool { return misses[i].Bee.ID < misses[j].Bee.ID })
+	profile.Wall = g.clock().Now().Sub(started)
+	return hits, misses, profile
+}
 
-		// Trigger damage event for stats monitoring
-		select {
-		case g.damageEvent <- damage:
-		default:
-			// Channel full, skip this event (non-blocking)
-		}
+// nextBeeSeed draws a seed for one bee decision from the bee RNG
+func (g *Game) nextBeeSeed() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-		if !playerAlive {
-			fmt.Println("💀 You have been stung to death! 💀")
-		}
-	} else if len(misses) > 0 {
-		// All bees missed - show a random miss
-		chosenMiss := misses[g.rng.Intn(len(misses))]
-		fmt.Printf("Buzz! That was close! The %s Bee just missed you!\n",
-			chosenMiss.Bee.Type.String())
-	}
+	return g.beeRng.Int63()
 }
 
 // makeBeeDecision simulates a bee making an attack decision concurrently
 func (g *Game) makeBeeDecision(bee *Bee) BeeDecision {
-	start := time.Now()
-
 	// Create local RNG for this goroutine to avoid race conditions
-	localRng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return g.makeBeeDecisionWithRand(bee, rand.New(rand.NewSource(g.nextBeeSeed())))
+}
+
+// makeBeeDecisionWithRand simulates a bee's decision using the given goroutine-local RNG
+func (g *Game) makeBeeDecisionWithRand(bee *Bee, localRng *rand.Rand) BeeDecision {
+	clock := g.clock()
+	start := clock.Now()
 
 	// Simulate different thinking times based on bee type
 	var thinkingTime time.Duration
@@ -445,60 +3056,250 @@ func (g *Game) makeBeeDecision(bee *Bee) BeeDecision {
 		thinkingTime = time.Duration(10+localRng.Intn(40)) * time.Millisecond // 10-50ms
 	}
 
-	// Simulate thinking
-	time.Sleep(thinkingTime)
+	// Simulate thinking (the delay is still rolled so instant bees don't change the seeded rolls)
+	if !g.Config.DisableThinkDelay && !g.Config.InstantThink[bee.Type] {
+		clock.Sleep(thinkingTime)
+	}
 
 	// Make the hit/miss decision using local RNG
-	willHit := localRng.Float64() >= g.Config.BeesMissChance
+	willHit := localRng.Float64() >= g.EffectiveBeesMissChance()
 
 	return BeeDecision{
 		Bee:          bee,
 		WillHit:      willHit,
-		DecisionTime: time.Since(start),
+		DecisionTime: clock.Now().Sub(start),
+	}
+}
+
+// beeAttackDamage works out how much a sting from the given bee hurts this turn
+func (g *Game) beeAttackDamage(bee *Bee, berserk bool) int {
+	damage := bee.AttackDamage()
+	if berserk && bee.Type == Drone {
+		damage *= BerserkDamageMultiplier
+	}
+	damage <<= g.suddenDeathDoublings(g.currentTurn())
+	if g.Config.Berserker {
+		damage = berserkerScale(damage)
+	}
+
+	// Armor soaks up part of every sting
+	minDamage := g.Config.MinBeeDamage
+	if g.Config.PlayerArmor > 0 {
+		damage -= g.Config.PlayerArmor
+		minDamage = max(minDamage, 1)
+		if g.Config.ArmorFullBlock {
+			minDamage = 0
+		}
+	}
+
+	// After every modifier a sting still hurts at least the floor, unless armor may block it fully
+	if damage < minDamage {
+		damage = minDamage
+	}
+	return damage
+}
+
+// suddenDeathDoublings is how many times bee damage doubles on a turn: none before the threshold, then one more every turn
+func (g *Game) suddenDeathDoublings(turn int) int {
+	if g.Config.SuddenDeathTurn <= 0 || turn < g.Config.SuddenDeathTurn {
+		return 0
+	}
+	doublings := turn - g.Config.SuddenDeathTurn + 1
+	if doublings > SuddenDeathMaxDoublings {
+		doublings = SuddenDeathMaxDoublings
 	}
+	return doublings
 }
 
 // getDamageDealtTo tells you how much damage each bee type takes when hit
 func (g *Game) getDamageDealtTo(beeType BeeType) int {
-	return BeeStatsTable[beeType].TakesDamage
+	damage := g.Config.beeStats(beeType).TakesDamage
+	if multiplier := g.resistanceMultiplier(beeType); multiplier != 1 {
+		damage = int(math.Round(float64(damage) * multiplier))
+		if damage < 1 {
+			damage = 1
+		}
+	}
+	if g.Config.Berserker {
+		damage = berserkerScale(damage)
+	}
+	return damage
+}
+
+// HitsToClear returns the fewest player hits (ignoring misses) needed to kill every remaining bee,
+// taking the shortcut through the Queen when her death wipes out the hive. A shielded Queen
+// can only be reached once her Workers are dead, so their hits come first on that route.
+func (g *Game) HitsToClear() int {
+	aliveBees := g.GetAliveBees()
+
+	hitsToKill := func(bee *Bee) int {
+		damage := g.getDamageDealtTo(bee.Type)
+		return (bee.HP + damage - 1) / damage
+	}
+
+	total := 0
+	for _, bee := range aliveBees {
+		total += hitsToKill(bee)
+	}
+	if g.Config.DisableQueenWipe {
+		return total
+	}
+
+	spared := make(map[BeeType]bool)
+	for _, beeType := range g.Config.QueenWipeSpares {
+		spared[beeType] = true
+	}
+
+	// Kill the easiest Queen, after any Workers shielding her, then mop up whatever types survive the wipe
+	queenHits := -1
+	sparedHits := 0
+	for _, bee := range aliveBees {
+		if bee.Type == Queen && (queenHits < 0 || hitsToKill(bee) < queenHits) {
+			queenHits = hitsToKill(bee)
+		}
+		if spared[bee.Type] || (bee.Type == Worker && g.Config.QueenShielded) {
+			sparedHits += hitsToKill(bee)
+		}
+	}
+	if queenHits >= 0 && queenHits+sparedHits < total {
+		return queenHits + sparedHits
+	}
+	return total
+}
+
+// resistanceMultiplier is how much of the weapon's damage a bee type takes (1 without a weapon type)
+func (g *Game) resistanceMultiplier(beeType BeeType) float64 {
+	if g.Config.WeaponType == "" {
+		return 1
+	}
+	resistances := g.Config.Resistances
+	if resistances == nil {
+		resistances = DefaultResistances
+	}
+	if multiplier, ok := resistances[beeType][g.Config.WeaponType]; ok {
+		return multiplier
+	}
+	return 1
+}
+
+// berserkerScale applies the berserker bonus to a damage value, rounding up so it always bites
+func berserkerScale(damage int) int {
+	return (damage*(100+BerserkerBonusPercent) + 99) / 100
 }
 
 // EndGame shows the final results and says goodbye
 func (g *Game) EndGame() {
+	if g.Config.JSONSummary {
+		g.printJSONSummary()
+		return
+	}
+
+	result := g.Result()
+
 	g.mu.RLock()
 	playerAlive := g.Player.IsAlive()
 	turns := g.Turns
 	playerHP := g.Player.HP
 	playerMaxHP := g.Player.MaxHP
-	totalBees := g.Config.QueenCount + g.Config.WorkerCount + g.Config.DroneCount
 	g.mu.RUnlock()
-
-	fmt.Println("\n" + strings.Repeat("=", 50))
-	fmt.Println("                 GAME OVER")
-	fmt.Println(strings.Repeat("=", 50))
-
-	if playerAlive {
-		fmt.Println("🎉 CONGRATULATIONS! YOU WON! 🎉")
-		fmt.Printf("You successfully destroyed the hive in %d turns!\n", turns)
+	totalBees := g.totalBees()
+
+	fmt.Fprintln(g.out(), "\n"+strings.Repeat("=", 50))
+	fmt.Fprintln(g.out(), "                 GAME OVER")
+	fmt.Fprintln(g.out(), strings.Repeat("=", 50))
+
+	if result.MutualDefeat {
+		fmt.Fprintln(g.out(), "☠️ MUTUAL DEFEAT ☠️")
+		fmt.Fprintf(g.out(), "You and the last of the hive fell together after %d turns.\n", turns)
+	} else if playerAlive {
+		if !g.printEndTemplate(g.Config.WinTemplate, result) {
+			fmt.Fprintln(g.out(), "🎉 CONGRATULATIONS! YOU WON! 🎉")
+			fmt.Fprintf(g.out(), "You successfully destroyed the hive in %d turns!\n", turns)
+		}
 	} else {
-		fmt.Println("💀 GAME OVER - YOU DIED 💀")
-		fmt.Printf("The bees defeated you after %d turns.\n", turns)
+		if !g.printEndTemplate(g.Config.LoseTemplate, result) {
+			fmt.Fprintln(g.out(), "💀 GAME OVER - YOU DIED 💀")
+			fmt.Fprintf(g.out(), "The bees defeated you after %d turns.\n", turns)
+		}
 	}
 
 	// Show how the battle went
-	fmt.Println("\n--- GAME SUMMARY ---")
-	fmt.Printf("Total turns: %d\n", turns)
-	fmt.Printf("Final player HP: %d/%d\n", playerHP, playerMaxHP)
+	fmt.Fprintln(g.out(), "\n--- GAME SUMMARY ---")
+	fmt.Fprintf(g.out(), "Total turns: %d\n", turns)
+	fmt.Fprintf(g.out(), "Final player HP: %d/%d\n", playerHP, playerMaxHP)
 
-	aliveBees := g.GetAliveBees()
-	fmt.Printf("Bees remaining: %d/%d\n", len(aliveBees), totalBees)
+	status := g.Status()
+	fmt.Fprintf(g.out(), "Bees remaining: %d/%d\n", status.AliveBees, totalBees)
+
+	if status.AliveBees > 0 {
+		fmt.Fprintf(g.out(), "  Queens: %d, Workers: %d, Drones: %d\n", status.Queens, status.Workers, status.Drones)
+	}
+
+	if mvp, damage := g.MostDangerousBee(); mvp != nil {
+		if mvp.Name != "" {
+			fmt.Fprintf(g.out(), "Most dangerous: %s #%d dealt %d damage\n", mvp.describe("a"), mvp.ID, damage)
+		} else {
+			fmt.Fprintf(g.out(), "Most dangerous: %s #%d dealt %d damage\n", mvp.Type, mvp.ID, damage)
+		}
+	}
+
+	g.mu.RLock()
+	dealt, taken := g.DamageDealt, g.DamageTaken
+	g.mu.RUnlock()
+	fmt.Fprintf(g.out(), "You dealt %d damage and took %d.\n", dealt, taken)
+
+	byType := g.DamageByType()
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		damage, ok := byType[beeType]
+		if !ok {
+			continue
+		}
+		kills := "kills"
+		if damage.Kills == 1 {
+			kills = "kill"
+		}
+		fmt.Fprintf(g.out(), "  %ss: %d HP removed across %d %s\n", beeType, damage.HPRemoved, damage.Kills, kills)
+	}
+
+	if g.Config.BossRush {
+		g.mu.RLock()
+		survived := g.Round - 1
+		if result.PlayerWon {
+			survived = g.Round
+		}
+		g.mu.RUnlock()
+		fmt.Fprintf(g.out(), "Boss rush rounds survived: %d/%d\n", survived, g.Config.bossRushRounds())
+	}
 
-	if len(aliveBees) > 0 {
-		queens := g.GetBeesByType(Queen)
-		workers := g.GetBeesByType(Worker)
-		drones := g.GetBeesByType(Drone)
-		fmt.Printf("  Queens: %d, Workers: %d, Drones: %d\n", len(queens), len(workers), len(drones))
+	if lives := g.Config.lives(); lives > 1 {
+		g.mu.RLock()
+		used := lives - g.LivesLeft + 1
+		g.mu.RUnlock()
+		fmt.Fprintf(g.out(), "Lives used: %d/%d\n", used, lives)
+	}
+
+	g.ScoreBreakdown().Print(g.out())
+
+	fmt.Fprintln(g.out(), "\nThanks for playing Bees in the Trap!")
+}
+
+// printEndTemplate renders a custom end-of-game message, reporting false if the default should be used.
+// A template that fails to render is reported before falling back to the default.
+func (g *Game) printEndTemplate(text string, result GameResult) bool {
+	if text == "" {
+		return false
+	}
+
+	var message strings.Builder
+	tmpl, err := template.New("end").Parse(text)
+	if err == nil {
+		err = tmpl.Execute(&message, result)
+	}
+	if err != nil {
+		fmt.Fprintf(g.out(), "⚠️ Couldn't render the end-of-game message: %v\n", err)
+		return false
 	}
 
-	fmt.Println("\nThanks for playing Bees in the Trap!")
+	fmt.Fprintln(g.out(), strings.TrimRight(message.String(), "\n"))
+	return true
 }
@@ -2,10 +2,14 @@ package game
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -349,6 +353,7 @@ func TestConcurrentBeeDecisions(t *testing.T) {
 // Test damage event channel
 func TestDamageEventChannel(t *testing.T) {
 	game := NewGame()
+	defer game.Close() // Wait for the monitor to report before the next test swaps stdout
 
 	// Give the goroutine time to start
 	time.Sleep(10 * time.Millisecond)
@@ -466,7 +471,8 @@ func TestBeeTypeString(t *testing.T) {
 // Test NewGame damage event monitoring goroutine
 func TestNewGameDamageEventMonitoring(t *testing.T) {
 	game := NewGame()
-	game.Turns = 1 // Enable damage event processing
+	defer game.Close() // Wait for the monitor to report before the next test swaps stdout
+	game.Turns = 1     // Enable damage event processing
 
 	// Give the goroutine time to start
 	time.Sleep(10 * time.Millisecond)
@@ -568,8 +574,8 @@ func TestBeeTurnPlayerDeath(t *testing.T) {
 	game.Hive[Drone] = []*Bee{bee}
 	game.AliveBees = []*Bee{bee}
 
-	// Set seed to ensure hit
-	game.rng = rand.New(rand.NewSource(1))
+	// Bees never miss, so the sting is guaranteed to land
+	game.Config.BeesMissChance = 0
 
 	// Capture stdout to verify death message
 	oldStdout := os.Stdout
@@ -721,3 +727,396 @@ func TestEndGamePlayerVictory(t *testing.T) {
 		}
 	}
 }
+
+// Test EndGame reports a mutual defeat when the player and hive fall together
+func TestEndGameMutualDefeat(t *testing.T) {
+	game := NewGame()
+	game.Turns = 12
+	game.Player.HP = 0 // Player stung to death...
+	game.KillAllBees() // ...the same turn the hive was destroyed
+
+	result := game.Result()
+	if !result.Over || !result.MutualDefeat {
+		t.Errorf("Expected a finished mutual defeat, got %+v", result)
+	}
+	if result.PlayerWon {
+		t.Error("A mutual defeat should not count as a player win")
+	}
+
+	output := captureStdout(game.EndGame)
+
+	expectedPhrases := []string{
+		"MUTUAL DEFEAT",
+		"You and the last of the hive fell together after 12 turns.",
+	}
+	for _, phrase := range expectedPhrases {
+		if !strings.Contains(output, phrase) {
+			t.Errorf("Expected EndGame() mutual defeat output to contain '%s', but it didn't. Output: %s", phrase, output)
+		}
+	}
+	if strings.Contains(output, "YOU WON") || strings.Contains(output, "YOU DIED") {
+		t.Errorf("Mutual defeat should not print the win or death banner. Output: %s", output)
+	}
+}
+
+// Test Result distinguishes wins, losses and unfinished games
+func TestGameResultOutcomes(t *testing.T) {
+	game := NewGame()
+	if result := game.Result(); result.Over || result.PlayerWon || result.MutualDefeat {
+		t.Errorf("Expected an unfinished game at start, got %+v", result)
+	}
+
+	game.Player.HP = 0
+	if result := game.Result(); !result.Over || result.PlayerWon || result.MutualDefeat {
+		t.Errorf("Expected a plain loss, got %+v", result)
+	}
+
+	game = NewGame()
+	game.KillAllBees()
+	if result := game.Result(); !result.Over || !result.PlayerWon || result.MutualDefeat {
+		t.Errorf("Expected a plain win, got %+v", result)
+	}
+}
+
+// Test EndGame renders a custom win template and falls back to the default lose text
+func TestEndGameWinTemplate(t *testing.T) {
+	config := DefaultConfig()
+	config.WinTemplate = "The hive fell in {{.Turns}} turns and you kept {{.PlayerHP}} HP!"
+	game := NewGameWithConfig(config)
+	game.Turns = 9
+	game.Player.HP = 42
+	game.KillAllBees()
+
+	output := captureStdout(game.EndGame)
+
+	if !strings.Contains(output, "The hive fell in 9 turns and you kept 42 HP!") {
+		t.Errorf("Expected interpolated win template, got: %s", output)
+	}
+	if strings.Contains(output, "CONGRATULATIONS") {
+		t.Errorf("Custom win template should replace the default text, got: %s", output)
+	}
+
+	// No lose template configured, so a defeat uses the default text
+	game = NewGameWithConfig(config)
+	game.Player.HP = 0
+	output = captureStdout(game.EndGame)
+	if !strings.Contains(output, "💀 GAME OVER - YOU DIED 💀") {
+		t.Errorf("Expected default defeat text without a lose template, got: %s", output)
+	}
+
+	// A template that fails to render is reported and the default text used instead
+	config.WinTemplate = "You won with {{.Gold}} gold!"
+	game = NewGameWithConfig(config)
+	game.KillAllBees()
+	output = captureStdout(game.EndGame)
+	if !strings.Contains(output, "Couldn't render the end-of-game message") || !strings.Contains(output, "CONGRATULATIONS") {
+		t.Errorf("Expected the render error reported and the default win text, got: %s", output)
+	}
+}
+
+// Test Validate rejects malformed end-of-game templates
+func TestConfigValidateTemplates(t *testing.T) {
+	config := DefaultConfig()
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected default config to be valid, got %v", err)
+	}
+
+	config.WinTemplate = "You won in {{.Turns"
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "win template") {
+		t.Errorf("Expected a win template error, got %v", err)
+	}
+
+	config = DefaultConfig()
+	config.LoseTemplate = "{{if .Turns}}never closed"
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "lose template") {
+		t.Errorf("Expected a lose template error, got %v", err)
+	}
+}
+
+// Test turns can advance while status readers run concurrently (run with -race to check locking)
+func TestTurnCounterConcurrentAccess(t *testing.T) {
+	game := NewGame()
+	game.Out = io.Discard
+
+	const turns = 200
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// Readers poll every view of the turn counter until the writers finish
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					game.currentTurn()
+					game.Status()
+					game.PrintGameStatus()
+					game.notifyDamage(1)
+				}
+			}
+		}()
+	}
+
+	var writers sync.WaitGroup
+	for i := 0; i < turns; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			game.PlayerTurn("")
+		}()
+	}
+	writers.Wait()
+	close(done)
+	wg.Wait()
+
+	if got := game.currentTurn(); got != turns {
+		t.Errorf("Expected %d turns after concurrent increments, got %d", turns, got)
+	}
+	if status := game.Status(); status.Turns != turns {
+		t.Errorf("Expected status to report %d turns, got %d", turns, status.Turns)
+	}
+}
+
+// Test Validate rejects hives larger than the configured maximum
+func TestConfigValidateMaxHiveSize(t *testing.T) {
+	config := DefaultConfig()
+	config.DroneCount = 1000000
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "maximum hive size") {
+		t.Errorf("Expected a million Drones to exceed the default maximum, got %v", err)
+	}
+
+	config = DefaultConfig()
+	config.MaxHiveSize = 30
+	if err := config.Validate(); err == nil {
+		t.Errorf("Expected the %d-bee default hive to exceed a maximum of 30", DefaultTotalBees)
+	}
+
+	config.MaxHiveSize = DefaultTotalBees
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected a hive exactly at the maximum to be valid, got %v", err)
+	}
+}
+
+// Test NewGameChecked refuses a config Validate rejects and builds a game from a valid one
+func TestNewGameChecked(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableMonitor = true
+	config.DroneCount = 1000000
+	if game, err := NewGameChecked(config); err == nil || game != nil {
+		t.Errorf("Expected a million Drones to be refused, got %v", err)
+	}
+
+	config.DroneCount = DefaultDroneCount
+	game, err := NewGameChecked(config)
+	if err != nil || game == nil {
+		t.Fatalf("Expected a game from the default config, got %v", err)
+	}
+	if bees := len(game.GetAliveBees()); bees != DefaultTotalBees {
+		t.Errorf("Expected a full hive of %d bees, got %d", DefaultTotalBees, bees)
+	}
+}
+
+// Test GameConfig serializes every field to JSON and round-trips
+func TestGameConfigJSON(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerSeed = 12
+	config.WeaponType = DamageBlunt
+	config.QueenWipeSpares = []BeeType{Drone}
+	config.TargetWeights = map[BeeType]float64{Queen: 2, Worker: 1}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to decode config JSON: %v", err)
+	}
+	if len(fields) != reflect.TypeOf(config).NumField() {
+		t.Errorf("Expected %d JSON fields, got %d: %s", reflect.TypeOf(config).NumField(), len(fields), data)
+	}
+
+	expected := map[string]interface{}{
+		"player_hp":          float64(PlayerStartingHP),
+		"player_miss_chance": DefaultPlayerMissChance,
+		"drone_count":        float64(DefaultDroneCount),
+		"player_seed":        float64(12),
+		"weapon_type":        DamageBlunt,
+		"disable_queen_wipe": false,
+		"queen_wipe_spares":  []interface{}{"Drone"},
+		"target_weights":     map[string]interface{}{"Queen": float64(2), "Worker": float64(1)},
+	}
+	for field, value := range expected {
+		if !reflect.DeepEqual(fields[field], value) {
+			t.Errorf("Expected %s to be %v, got %v", field, value, fields[field])
+		}
+	}
+
+	var decoded GameConfig
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, config) {
+		t.Errorf("Expected config to round-trip through JSON, got %+v", decoded)
+	}
+}
+
+// Test every outcome maps to its own exit code
+func TestGameResultExitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		result GameResult
+		want   int
+	}{
+		{"win", GameResult{Over: true, PlayerWon: true, PlayerHP: 40}, ExitWin},
+		{"loss", GameResult{Over: true, BeesRemaining: 3}, ExitLoss},
+		{"draw", GameResult{Over: true, MutualDefeat: true}, ExitDraw},
+		{"unfinished", GameResult{PlayerHP: 100, BeesRemaining: 31}, ExitUnfinished},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.ExitCode(); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, got)
+			}
+		})
+	}
+
+	codes := map[int]bool{ExitWin: true, ExitLoss: true, ExitDraw: true, ExitUnfinished: true, ExitError: true}
+	if len(codes) != 5 {
+		t.Error("Expected every outcome and errors to have distinct exit codes")
+	}
+}
+
+// Test PlayGame reports a quit before the end as unfinished
+func TestPlayGameReturnsResult(t *testing.T) {
+	game := NewGame()
+	game.Out = io.Discard
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer w.Close()
+		w.Write([]byte("quit\n"))
+	}()
+	result := game.PlayGame()
+	os.Stdin = oldStdin
+
+	if result.Over || result.ExitCode() != ExitUnfinished {
+		t.Errorf("Expected an unfinished result after quitting, got %+v", result)
+	}
+}
+
+// Test callers can iterate a GetAliveBees slice while attacks keep changing the hive (run with -race)
+func TestGetAliveBeesSnapshotRace(t *testing.T) {
+	config := DefaultConfig()
+	config.DroneCount = 200
+	config.PlayerMissChance = 0
+	config.DisableQueenWipe = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 300; i++ {
+			game.PlayerAttack()
+		}
+	}()
+
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+
+		snapshot := game.GetAliveBees()
+		length := len(snapshot)
+		seen := make(map[int]bool, length)
+		for _, bee := range snapshot {
+			if seen[bee.ID] {
+				t.Fatalf("Bee #%d appears twice in one snapshot", bee.ID)
+			}
+			seen[bee.ID] = true
+		}
+		if len(snapshot) != length {
+			t.Fatal("The snapshot changed length while being iterated")
+		}
+	}
+}
+
+// Test SeedFromConfig makes identical configs replay identical games
+func TestSeedFromConfigReproducesGames(t *testing.T) {
+	config := DefaultConfig()
+	config.SeedFromConfig = true
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+
+	play := func() []TurnRecord {
+		game := NewGameWithConfig(config)
+		game.Out = io.Discard
+		for turn := 0; turn < 20 && !game.IsGameOver(); turn++ {
+			if err := game.Step("hit"); err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+		}
+		return game.Transcript()
+	}
+
+	first := play()
+	if len(first) == 0 {
+		t.Fatal("Expected the game to record a transcript")
+	}
+	if second := play(); !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected identical transcripts from identical configs:\n%+v\n%+v", first, second)
+	}
+
+	other := config
+	other.PlayerHP++
+	if other.Fingerprint() == config.Fingerprint() {
+		t.Error("Expected a different config to fingerprint differently")
+	}
+
+	// Display options don't change the game, so they keep the fingerprint
+	shown := config
+	shown.TurnSummary = true
+	shown.AutosavePath = "autosave.json"
+	shown.AutoModeDelay = 0
+	if shown.Fingerprint() != config.Fingerprint() {
+		t.Error("Expected display-only options to keep the fingerprint")
+	}
+}
+
+// Test a seed from the environment drives the game's rolls
+func TestSeedFromEnv(t *testing.T) {
+	t.Setenv(SeedEnvVar, "42")
+	seed, ok, err := SeedFromEnv()
+	if err != nil || !ok || seed != 42 {
+		t.Fatalf("Expected seed 42 from the environment, got %d (%v, %v)", seed, ok, err)
+	}
+
+	config := DefaultConfig()
+	config.PlayerSeed = seed
+	game := NewGameWithConfig(config)
+	if roll, want := game.rng.Float64(), rand.New(rand.NewSource(42)).Float64(); roll != want {
+		t.Errorf("Expected the first roll %v from seed 42, got %v", want, roll)
+	}
+
+	t.Setenv(SeedEnvVar, "")
+	if _, ok, err := SeedFromEnv(); ok || err != nil {
+		t.Errorf("Expected no seed from an empty variable, got %v, %v", ok, err)
+	}
+
+	t.Setenv(SeedEnvVar, "bees")
+	if _, _, err := SeedFromEnv(); err == nil {
+		t.Error("Expected an error for a non-numeric seed")
+	}
+}
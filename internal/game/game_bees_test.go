@@ -0,0 +1,995 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureStdout runs fn while collecting everything it prints to stdout
+func captureStdout(fn func()) string {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String()
+}
+
+// newSingleBeeGame builds a game whose hive is a single bee of the given type that never misses.
+// The monitor is off, since these tests poke game state directly between turns.
+func newSingleBeeGame(config GameConfig, beeType BeeType) *Game {
+	config.QueenCount = 0
+	config.WorkerCount = 0
+	config.DroneCount = 0
+	switch beeType {
+	case Queen:
+		config.QueenCount = 1
+	case Worker:
+		config.WorkerCount = 1
+	case Drone:
+		config.DroneCount = 1
+	}
+	config.BeesMissChance = 0
+	config.DisableMonitor = true
+	return NewGameWithConfig(config)
+}
+
+// Test berserk drones double their damage for a single bee turn
+func TestBeeTurnBerserkDrones(t *testing.T) {
+	config := DefaultConfig()
+	config.BerserkChance = 1.0
+	config.BeeSeed = 7
+	game := newSingleBeeGame(config, Drone)
+
+	output := captureStdout(game.BeeTurn)
+
+	if !strings.Contains(output, "The drones go berserk!") {
+		t.Errorf("Expected berserk message, got: %s", output)
+	}
+	if game.Player.HP != 100-2*DroneDamage {
+		t.Errorf("Expected berserk Drone to deal %d damage, player has %d HP", 2*DroneDamage, game.Player.HP)
+	}
+
+	// The buff must not stick to the bee itself
+	drone := game.GetBeesByType(Drone)[0]
+	if drone.Damage != DroneDamage {
+		t.Errorf("Expected Drone base damage to stay %d, got %d", DroneDamage, drone.Damage)
+	}
+
+	// Next turn without the event the Drone stings normally again
+	game.Config.BerserkChance = 0
+	hpBefore := game.Player.HP
+	output = captureStdout(game.BeeTurn)
+
+	if strings.Contains(output, "berserk") {
+		t.Errorf("Did not expect berserk message on the following turn, got: %s", output)
+	}
+	if hpBefore-game.Player.HP != DroneDamage {
+		t.Errorf("Expected normal Drone damage %d on the following turn, got %d", DroneDamage, hpBefore-game.Player.HP)
+	}
+}
+
+// Test player armor reduces incoming sting damage
+func TestBeeTurnPlayerArmor(t *testing.T) {
+	tests := []struct {
+		name           string
+		beeType        BeeType
+		fullBlock      bool
+		expectedDamage int
+	}{
+		{"Worker reduced by armor", Worker, false, WorkerDamage - 3},
+		{"Drone clamped to minimum", Drone, false, 1},
+		{"Drone fully blocked", Drone, true, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.PlayerArmor = 3
+			config.ArmorFullBlock = test.fullBlock
+			game := newSingleBeeGame(config, test.beeType)
+
+			output := captureStdout(game.BeeTurn)
+
+			damageTaken := game.Player.MaxHP - game.Player.HP
+			if damageTaken != test.expectedDamage {
+				t.Errorf("Expected %s sting to deal %d damage through armor, got %d", test.beeType, test.expectedDamage, damageTaken)
+			}
+			if test.expectedDamage == 0 && !strings.Contains(output, "armor completely absorbed") {
+				t.Errorf("Expected armor absorb message, got: %s", output)
+			}
+		})
+	}
+}
+
+// Test MinBeeDamage keeps heavily armored stings above the floor, while shields still block them
+func TestMinBeeDamageFloor(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerArmor = 50 // Soaks up every sting several times over
+	config.MinBeeDamage = 4
+	game := newSingleBeeGame(config, Worker)
+
+	captureStdout(game.BeeTurn)
+	if damageTaken := game.Player.MaxHP - game.Player.HP; damageTaken != 4 {
+		t.Errorf("Expected the sting to deal the 4 damage floor through heavy armor, got %d", damageTaken)
+	}
+
+	game = newSingleBeeGame(config, Worker)
+	game.HasShield = true
+	captureStdout(game.BeeTurn)
+	if game.Player.HP != game.Player.MaxHP {
+		t.Errorf("Expected a shield to block the sting entirely, took %d damage", game.Player.MaxHP-game.Player.HP)
+	}
+}
+
+// Test damage monitor icons follow the configured thresholds
+func TestDamageIconThresholds(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		game := NewGameWithConfig(GameConfig{PlayerHP: 100}) // Zero thresholds fall back to defaults
+		expected := map[int]string{1: "🔸", 4: "🔸", 5: "⚡", 9: "⚡", 10: "🩸", 25: "🩸"}
+		for damage, icon := range expected {
+			if got := game.damageIcon(damage); got != icon {
+				t.Errorf("Default thresholds: damage %d expected %s, got %s", damage, icon, got)
+			}
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		config := DefaultConfig()
+		config.HighDamageThreshold = 50
+		config.MediumDamageThreshold = 20
+		game := NewGameWithConfig(config)
+		expected := map[int]string{10: "🔸", 19: "🔸", 20: "⚡", 49: "⚡", 50: "🩸", 80: "🩸"}
+		for damage, icon := range expected {
+			if got := game.damageIcon(damage); got != icon {
+				t.Errorf("Custom thresholds: damage %d expected %s, got %s", damage, icon, got)
+			}
+		}
+	})
+}
+
+// beeDecisionsByID rolls every living bee's decision and indexes whether it will hit by bee ID
+func beeDecisionsByID(game *Game) map[int]bool {
+	hits, misses, _ := game.collectBeeDecisions(game.GetAliveBees())
+	decisions := make(map[int]bool)
+	for _, decision := range hits {
+		decisions[decision.Bee.ID] = true
+	}
+	for _, decision := range misses {
+		decisions[decision.Bee.ID] = false
+	}
+	return decisions
+}
+
+// Test player and bee seeds control their randomness independently
+func TestPlayerAndBeeSeedsAreIndependent(t *testing.T) {
+	playTargets := func(beeSeed int64) ([]int, []map[int]bool) {
+		config := DefaultConfig()
+		config.PlayerSeed = 99
+		config.BeeSeed = beeSeed
+		config.PlayerMissChance = 0.3
+		config.BeesMissChance = 0.5
+		game := NewGameWithConfig(config)
+
+		var targets []int
+		var decisions []map[int]bool
+		for round := 0; round < 3; round++ {
+			targets = append(targets, game.selectTarget(game.GetAliveBees()).ID)
+			decisions = append(decisions, beeDecisionsByID(game))
+		}
+		return targets, decisions
+	}
+
+	targetsA, decisionsA := playTargets(1)
+	targetsB, decisionsB := playTargets(2)
+	targetsA2, decisionsA2 := playTargets(1)
+
+	if !reflect.DeepEqual(targetsA, targetsB) {
+		t.Errorf("Expected player targeting to ignore the bee seed, got %v vs %v", targetsA, targetsB)
+	}
+	if reflect.DeepEqual(decisionsA, decisionsB) {
+		t.Error("Expected different bee seeds to change bee decisions")
+	}
+	if !reflect.DeepEqual(decisionsA, decisionsA2) || !reflect.DeepEqual(targetsA, targetsA2) {
+		t.Error("Expected the same seeds to reproduce the same game")
+	}
+}
+
+// Test surviving bees hit harder each turn when leveling is enabled
+func TestBeeLevelingIncreasesDamage(t *testing.T) {
+	config := DefaultConfig()
+	config.BeeLeveling = true
+	game := newSingleBeeGame(config, Worker)
+	worker := game.GetBeesByType(Worker)[0]
+
+	for turn := 0; turn < 3; turn++ {
+		hpBefore := game.Player.HP
+		captureStdout(game.BeeTurn)
+
+		expected := WorkerDamage + turn*BeeDamagePerLevel
+		if taken := hpBefore - game.Player.HP; taken != expected {
+			t.Errorf("Turn %d: expected Worker to deal %d damage, got %d", turn+1, expected, taken)
+		}
+	}
+
+	if worker.Level != 3 {
+		t.Errorf("Expected Worker to reach level 3, got %d", worker.Level)
+	}
+	if worker.Damage != WorkerDamage {
+		t.Errorf("Expected base damage to stay %d, got %d", WorkerDamage, worker.Damage)
+	}
+}
+
+// Test bee levels are capped
+func TestBeeLevelCap(t *testing.T) {
+	bee := NewBee(Drone)
+	for i := 0; i < BeeMaxLevel+3; i++ {
+		bee.LevelUp()
+	}
+
+	if bee.Level != BeeMaxLevel {
+		t.Errorf("Expected level to cap at %d, got %d", BeeMaxLevel, bee.Level)
+	}
+	if bee.LevelUp() {
+		t.Error("LevelUp should report no change at the cap")
+	}
+	if bee.AttackDamage() != DroneDamage+BeeMaxLevel*BeeDamagePerLevel {
+		t.Errorf("Unexpected capped attack damage %d", bee.AttackDamage())
+	}
+}
+
+// Test a large hive with a small concurrency limit still collects every decision
+func TestMaxConcurrentDecisions(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 0
+	config.DroneCount = 200
+	config.MaxConcurrentDecisions = 20
+	config.BeeSeed = 11
+	game := NewGameWithConfig(config)
+
+	hits, misses, _ := game.collectBeeDecisions(game.GetAliveBees())
+	if len(hits)+len(misses) != 200 {
+		t.Fatalf("Expected 200 decisions, got %d", len(hits)+len(misses))
+	}
+
+	// Every bee decided exactly once
+	seen := make(map[int]bool)
+	for _, decision := range append(hits, misses...) {
+		if seen[decision.Bee.ID] {
+			t.Errorf("Bee #%d decided more than once", decision.Bee.ID)
+		}
+		seen[decision.Bee.ID] = true
+	}
+
+	// The limit doesn't change what a seeded hive decides
+	limited := NewGameWithConfig(config)
+	config.MaxConcurrentDecisions = 0 // Falls back to DefaultMaxConcurrentDecisions
+	defaulted := NewGameWithConfig(config)
+	if !reflect.DeepEqual(beeDecisionsByID(limited), beeDecisionsByID(defaulted)) {
+		t.Error("Expected the same seeded decisions with a custom and the default concurrency limit")
+	}
+}
+
+// Test swarm pressure chips the player in proportion to the living hive
+func TestSwarmPressure(t *testing.T) {
+	config := DefaultConfig()
+	config.SwarmPressure = true
+	game := NewGameWithConfig(config)
+
+	// A full hive of 31 bees deals 3 chip damage
+	captureStdout(game.applySwarmPressure)
+	if game.Player.HP != 97 {
+		t.Errorf("Expected 3 swarm pressure damage from 31 bees, player has %d HP", game.Player.HP)
+	}
+
+	// Thin the hive to 15 bees for 1 chip damage
+	for _, drone := range game.GetBeesByType(Drone)[:16] {
+		drone.HP = 0
+	}
+	output := captureStdout(game.applySwarmPressure)
+	if game.Player.HP != 96 {
+		t.Errorf("Expected 1 swarm pressure damage from 15 bees, player has %d HP", game.Player.HP)
+	}
+	if !strings.Contains(output, "Swarm pressure!") {
+		t.Errorf("Expected swarm pressure message, got: %s", output)
+	}
+
+	// Fewer than 10 bees can't apply any pressure
+	for _, drone := range game.GetBeesByType(Drone) {
+		drone.HP = 0
+	}
+	output = captureStdout(game.applySwarmPressure)
+	if game.Player.HP != 96 || output != "" {
+		t.Errorf("Expected no swarm pressure from 6 bees, player has %d HP, output: %s", game.Player.HP, output)
+	}
+
+	// A fatal dose of pressure can be survived with a revive, just like a fatal sting
+	config.ReviveChance = 1
+	config.ReviveHP = 25
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.HP = 1
+	game.applySwarmPressure()
+	if game.Player.HP != 25 || !game.Revived {
+		t.Errorf("Expected swarm pressure to trigger the revive, got %d HP (revived %v)", game.Player.HP, game.Revived)
+	}
+}
+
+// Test swarm pressure is applied as part of the bee turn
+func TestBeeTurnAppliesSwarmPressure(t *testing.T) {
+	config := DefaultConfig()
+	config.SwarmPressure = true
+	config.BeesMissChance = 1.0 // Isolate the chip damage from stings
+	game := NewGameWithConfig(config)
+
+	captureStdout(game.BeeTurn)
+	if game.Player.HP != 97 {
+		t.Errorf("Expected only swarm pressure damage during an all-miss bee turn, player has %d HP", game.Player.HP)
+	}
+
+	// Disabled by default
+	game = NewGame()
+	game.Config.BeesMissChance = 1.0
+	captureStdout(game.BeeTurn)
+	if game.Player.HP != 100 {
+		t.Errorf("Expected no swarm pressure by default, player has %d HP", game.Player.HP)
+	}
+}
+
+// Test reinforcements add Drones to the hive up to the configured cap
+func TestReinforcements(t *testing.T) {
+	config := DefaultConfig()
+	config.ReinforcementChance = 1.0
+	config.MaxReinforcements = 2
+	config.BeesMissChance = 1.0
+	config.BeeSeed = 5
+	game := NewGameWithConfig(config)
+
+	output := captureStdout(game.BeeTurn)
+	if !strings.Contains(output, "Reinforcements!") {
+		t.Errorf("Expected reinforcement announcement, got: %s", output)
+	}
+	if drones := len(game.GetBeesByType(Drone)); drones != DefaultDroneCount+1 {
+		t.Errorf("Expected %d Drones after a reinforcement, got %d", DefaultDroneCount+1, drones)
+	}
+	if alive := len(game.GetAliveBees()); alive != DefaultTotalBees+1 {
+		t.Errorf("Expected the new Drone in the alive list, got %d bees", alive)
+	}
+
+	// Further spawns stop at the cap
+	for i := 0; i < 3; i++ {
+		captureStdout(game.rollReinforcement)
+	}
+	if game.Reinforcements != 2 || len(game.GetBeesByType(Drone)) != DefaultDroneCount+2 {
+		t.Errorf("Expected reinforcements capped at 2, got %d (%d Drones)", game.Reinforcements, len(game.GetBeesByType(Drone)))
+	}
+
+	// Reinforcements die with the rest of the hive when the Queen falls
+	game.KillAllBees()
+	if len(game.GetAliveBees()) != 0 || !game.IsGameOver() {
+		t.Error("Expected the Queen wipe to take reinforcements with it")
+	}
+}
+
+// Test sudden death doubles bee damage from the threshold turn onwards
+func TestSuddenDeathDoublesBeeDamage(t *testing.T) {
+	config := DefaultConfig()
+	config.SuddenDeathTurn = 5
+	game := newSingleBeeGame(config, Worker)
+
+	game.Turns = 4
+	output := captureStdout(game.BeeTurn)
+	before := game.Player.MaxHP - game.Player.HP
+	if strings.Contains(output, "SUDDEN DEATH") {
+		t.Errorf("Did not expect a sudden death warning before the threshold, got: %s", output)
+	}
+
+	game.Turns = 5
+	hpBefore := game.Player.HP
+	output = captureStdout(game.BeeTurn)
+	after := hpBefore - game.Player.HP
+
+	if before != WorkerDamage || after != 2*before {
+		t.Errorf("Expected sting damage to double from %d to %d at the threshold, got %d and %d", WorkerDamage, 2*WorkerDamage, before, after)
+	}
+	if !strings.Contains(output, "SUDDEN DEATH! Bee stings deal x2 damage!") {
+		t.Errorf("Expected sudden death warning, got: %s", output)
+	}
+
+	// Every further turn escalates again
+	if got := game.beeAttackDamage(game.GetBeesByType(Worker)[0], false); got != 2*WorkerDamage {
+		t.Errorf("Expected x2 damage on the threshold turn, got %d", got)
+	}
+	game.Turns = 7
+	if got := game.beeAttackDamage(game.GetBeesByType(Worker)[0], false); got != 8*WorkerDamage {
+		t.Errorf("Expected x8 damage two turns past the threshold, got %d", got)
+	}
+}
+
+// Test a seeded BeeTurn narrates identically every run
+func TestSeededBeeTurnIsReproducible(t *testing.T) {
+	narrate := func() string {
+		config := DefaultConfig()
+		config.BeeSeed = 21
+		config.BeesMissChance = 0.5
+		config.DisableMonitor = true // The monitor prints asynchronously
+		game := NewGameWithConfig(config)
+
+		// Write to a private buffer so stray output from other games can't leak in
+		var buf bytes.Buffer
+		game.Out = &buf
+
+		var lines []string
+		for turn := 0; turn < 3; turn++ {
+			game.BeeTurn()
+			for _, line := range strings.Split(buf.String(), "\n") {
+				// Measured thinking time is wall-clock and can't be reproduced
+				if !strings.Contains(line, "Bees consulted") {
+					lines = append(lines, line)
+				}
+			}
+			buf.Reset()
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	first := narrate()
+	for run := 0; run < 3; run++ {
+		if again := narrate(); again != first {
+			t.Fatalf("Expected identical narration for the same seed, got:\n%s\n---\n%s", first, again)
+		}
+	}
+}
+
+// Test the bees can strike before the player's first attack
+func TestFirstStrikeBees(t *testing.T) {
+	config := DefaultConfig()
+	config.FirstStrike = FirstStrikeBees
+	config.PlayerMissChance = 0
+	config.DisableMonitor = true
+	game := newSingleBeeGame(config, Worker)
+
+	var buf bytes.Buffer
+	game.Out = &buf
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	output := buf.String()
+
+	sting := strings.Index(output, "Sting!")
+	attack := strings.Index(output, "Direct Hit!")
+	if sting < 0 || attack < 0 || sting > attack {
+		t.Errorf("Expected the Worker's sting before the player's attack, got: %s", output)
+	}
+	if !strings.Contains(output, "--- Turn 1: Bees Turn ---") || !strings.Contains(output, "--- Turn 1: Player Turn ---") {
+		t.Errorf("Expected both halves to belong to turn 1, got: %s", output)
+	}
+	if game.Turns != 1 || game.Player.HP != 100-WorkerDamage {
+		t.Errorf("Expected one turn with a %d damage sting, got %d turns and %d HP", WorkerDamage, game.Turns, game.Player.HP)
+	}
+
+	config.FirstStrike = "wasps"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an unknown first striker to be rejected")
+	}
+}
+
+// Test the bell rings on player death and Queen kills only when enabled
+func TestBellOnCriticalEvents(t *testing.T) {
+	config := DefaultConfig()
+	config.Bell = true
+	config.DisableMonitor = true
+	game := newSingleBeeGame(config, Worker)
+	game.Player.HP = 1
+
+	var buf bytes.Buffer
+	game.Out = &buf
+	game.BeeTurn()
+
+	if game.Player.IsAlive() {
+		t.Fatal("Expected the sting to kill the player")
+	}
+	if !strings.Contains(buf.String(), "\a") {
+		t.Errorf("Expected a BEL byte on player death, got: %q", buf.String())
+	}
+
+	buf.Reset()
+	game = NewGameWithConfig(config)
+	game.Out = &buf
+	primeQueenKill(game)
+	game.PlayerAttack()
+	if strings.Count(buf.String(), "\a") != 1 {
+		t.Errorf("Expected one BEL byte on the Queen kill, got: %q", buf.String())
+	}
+
+	// Off by default
+	buf.Reset()
+	game = NewGameWithConfig(DefaultConfig())
+	game.Out = &buf
+	primeQueenKill(game)
+	game.PlayerAttack()
+	if strings.Contains(buf.String(), "\a") {
+		t.Errorf("Did not expect a BEL byte without the Bell option, got: %q", buf.String())
+	}
+}
+
+// Test the bee that dealt the most damage is reported as the MVP
+func TestMostDangerousBee(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 2
+	config.DroneCount = 1
+	config.BeesMissChance = 0
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	if mvp, _ := game.MostDangerousBee(); mvp != nil {
+		t.Errorf("Expected no MVP before any sting, got %s #%d", mvp.Type, mvp.ID)
+	}
+
+	// Force the attackers: the second Worker stings twice, the Drone once
+	workers := game.GetBeesByType(Worker)
+	drone := game.GetBeesByType(Drone)[0]
+	game.stingPlayer(workers[1], false)
+	game.stingPlayer(drone, false)
+	game.stingPlayer(workers[1], false)
+
+	mvp, damage := game.MostDangerousBee()
+	if mvp != workers[1] || damage != 2*WorkerDamage {
+		t.Fatalf("Expected Worker #%d with %d damage as MVP, got %v with %d", workers[1].ID, 2*WorkerDamage, mvp, damage)
+	}
+
+	game.KillAllBees()
+	output := captureStdout(func() {
+		game.Out = nil
+		game.EndGame()
+	})
+	expected := fmt.Sprintf("Most dangerous: Worker #%d dealt %d damage", workers[1].ID, 2*WorkerDamage)
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected %q in the end game report, got: %s", expected, output)
+	}
+}
+
+// Test the hive enrages once it is thinned below EnrageBelow, buffing only the survivors
+func TestEnrageOnLowHive(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 4
+	config.DroneCount = 0
+	config.PlayerHP = 1000
+	config.EnrageBelow = 0.5
+	config.DisableThinkDelay = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	// Half the hive alive is not below the threshold
+	workers := game.GetBeesByType(Worker)
+	workers[0].HP = 0
+	workers[1].HP = 0
+	game.BeeTurn()
+	if game.Enraged {
+		t.Fatal("Expected no enrage with exactly half the hive alive")
+	}
+
+	workers[2].HP = 0
+	game.BeeTurn()
+	if !game.Enraged {
+		t.Fatal("Expected the hive to enrage with a quarter of it alive")
+	}
+	if workers[3].Damage != WorkerDamage+EnrageDamageBonus {
+		t.Errorf("Expected the survivor's damage to rise to %d, got %d", WorkerDamage+EnrageDamageBonus, workers[3].Damage)
+	}
+	if workers[0].Damage != WorkerDamage {
+		t.Errorf("Expected dead bees to keep their damage, got %d", workers[0].Damage)
+	}
+	if chance := game.EffectiveBeesMissChance(); chance != config.BeesMissChance*EnrageMissMultiplier {
+		t.Errorf("Expected the enraged miss chance to drop to %v, got %v", config.BeesMissChance*EnrageMissMultiplier, chance)
+	}
+
+	// The buff is applied once, not every turn
+	game.BeeTurn()
+	if workers[3].Damage != WorkerDamage+EnrageDamageBonus {
+		t.Errorf("Expected enrage to apply only once, damage is %d", workers[3].Damage)
+	}
+}
+
+// Test a second human picks the stinging bee through the same scripted input as the player
+func TestTwoPlayerBeeTurn(t *testing.T) {
+	config := DefaultConfig()
+	config.TwoPlayer = true
+	config.PlayerMissChance = 1 // Keep the hive intact so the bee IDs stay put
+	config.BeesMissChance = 0
+	game := NewGameWithConfig(config)
+	var out bytes.Buffer
+	game.Out = &out
+
+	// Player hits, hive stings with Worker #3, an unknown ID is re-asked, then the hive passes
+	game.In = strings.NewReader("hit\n3\nhit\n99\npass\nquit\n")
+	game.PlayGame()
+
+	if game.Player.HP != config.PlayerHP-WorkerDamage {
+		t.Errorf("Expected one Worker sting for %d damage, player has %d HP", WorkerDamage, game.Player.HP)
+	}
+	if bee, damage := game.MostDangerousBee(); bee == nil || bee.ID != 3 || damage != WorkerDamage {
+		t.Errorf("Expected bee #3 to have stung for %d, got %v with %d", WorkerDamage, bee, damage)
+	}
+	if !strings.Contains(out.String(), `No living bee has ID "99"`) {
+		t.Errorf("Expected an unknown ID to be rejected, got: %s", out.String())
+	}
+	if !strings.Contains(out.String(), "The hive holds back this turn.") {
+		t.Errorf("Expected the hive to pass on the second turn, got: %s", out.String())
+	}
+	if game.Turns != 2 {
+		t.Errorf("Expected 2 turns, got %d", game.Turns)
+	}
+}
+
+// Test the AI plays the hive in a two player game when nobody is at the keyboard to choose
+func TestTwoPlayerFallsBackToAI(t *testing.T) {
+	config := DefaultConfig()
+	config.TwoPlayer = true
+	config.PlayerMissChance = 1
+	config.BeesMissChance = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+
+	// Step drives the game for callers like the server, so it never prompts
+	game := NewGameWithConfig(config)
+	var out bytes.Buffer
+	game.Out = &out
+	game.In = strings.NewReader("") // A prompt would find no choice and the hive would pass
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if game.Player.HP >= config.PlayerHP {
+		t.Errorf("Expected the AI to sting during Step, player has %d HP", game.Player.HP)
+	}
+	if strings.Contains(out.String(), "Hive player") {
+		t.Errorf("Expected no hive player prompt during Step, got: %s", out.String())
+	}
+
+	// Auto mode plays the whole game itself, hive included
+	config.AutoModeDelay = 0
+	game = NewGameWithConfig(config)
+	out.Reset()
+	game.Out = &out
+	game.In = strings.NewReader("")
+	game.AutoMode = true
+	game.PlayGame()
+	if game.Player.IsAlive() || strings.Contains(out.String(), "Hive player") {
+		t.Errorf("Expected the AI hive to sting the player to death in auto mode, player has %d HP", game.Player.HP)
+	}
+}
+
+// Test instant bee types skip the think delay while other types still deliberate
+func TestInstantThinkPerType(t *testing.T) {
+	config := DefaultConfig()
+	config.InstantThink = map[BeeType]bool{Drone: true}
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+
+	drone := game.makeBeeDecisionWithRand(game.Hive[Drone][0], rand.New(rand.NewSource(1)))
+	if drone.DecisionTime > 5*time.Millisecond {
+		t.Errorf("Expected an instant Drone decision, took %v", drone.DecisionTime)
+	}
+
+	// Queens think for at least 50ms
+	queen := game.makeBeeDecisionWithRand(game.Hive[Queen][0], rand.New(rand.NewSource(1)))
+	if queen.DecisionTime < 50*time.Millisecond {
+		t.Errorf("Expected the Queen to keep deliberating, took %v", queen.DecisionTime)
+	}
+}
+
+// Test a Worker that stings dies and drops out of the living hive
+func TestWorkersDieOnSting(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 2
+	config.DroneCount = 0
+	config.BeesMissChance = 0
+	config.WorkersDieOnSting = true
+	config.DisableThinkDelay = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	game.BeeTurn()
+
+	if game.Player.HP != config.PlayerHP-WorkerDamage {
+		t.Fatalf("Expected one Worker sting, player has %d HP", game.Player.HP)
+	}
+	stinger, _ := game.MostDangerousBee()
+	if stinger == nil || stinger.IsAlive() {
+		t.Fatalf("Expected the stinging Worker to be dead, got %+v", stinger)
+	}
+	for _, bee := range game.AliveBees {
+		if bee.ID == stinger.ID {
+			t.Errorf("Expected Worker #%d to be removed from AliveBees", stinger.ID)
+		}
+	}
+	if len(game.GetAliveBees()) != 1 {
+		t.Errorf("Expected one Worker left, got %d", len(game.GetAliveBees()))
+	}
+}
+
+// Test adaptive aggression makes bees hit a healthy player more often than one near death
+func TestAdaptiveAggression(t *testing.T) {
+	config := DefaultConfig()
+	config.AdaptiveAggression = true
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	drone := game.Hive[Drone][0]
+
+	hitRate := func(playerHP int) int {
+		game.Player.HP = playerHP
+		rng := rand.New(rand.NewSource(99))
+		hits := 0
+		for i := 0; i < 2000; i++ {
+			if game.makeBeeDecisionWithRand(drone, rng).WillHit {
+				hits++
+			}
+		}
+		return hits
+	}
+
+	healthy := hitRate(config.PlayerHP)
+	nearDeath := hitRate(5)
+	if healthy <= nearDeath {
+		t.Errorf("Expected more hits on a healthy player, got %d at full HP and %d near death", healthy, nearDeath)
+	}
+
+	// The miss chance swings by AdaptiveAggressionSwing at either extreme
+	game.Player.HP = config.PlayerHP
+	if chance := game.EffectiveBeesMissChance(); math.Abs(chance-(config.BeesMissChance-AdaptiveAggressionSwing)) > 1e-9 {
+		t.Errorf("Expected a %.2f miss chance at full HP, got %v", config.BeesMissChance-AdaptiveAggressionSwing, chance)
+	}
+	game.Player.HP = 0
+	if chance := game.EffectiveBeesMissChance(); math.Abs(chance-(config.BeesMissChance+AdaptiveAggressionSwing)) > 1e-9 {
+		t.Errorf("Expected a %.2f miss chance at 0 HP, got %v", config.BeesMissChance+AdaptiveAggressionSwing, chance)
+	}
+}
+
+// Test a badly wounded Drone flees and rejoins the fight after FleeReturnTurns
+func TestFledBeeReturns(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowFlee = true
+	config.FleeChance = 1
+	config.FleeReturnTurns = 2
+	config.PlayerMissChance = 1 // Nothing else changes the hive
+	config.BeesMissChance = 1
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	drone := game.GetBeesByType(Drone)[0]
+	drone.HP = 5
+
+	isAlive := func() bool {
+		for _, bee := range game.GetAliveBees() {
+			if bee == drone {
+				return true
+			}
+		}
+		return false
+	}
+
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if isAlive() || len(game.Fled) != 1 {
+		t.Fatalf("Expected the wounded Drone to flee on turn 1, %d bees fled", len(game.Fled))
+	}
+
+	// While it is away the Drone can still be looked up
+	game.stingPlayer(drone, false)
+	if mvp, _ := game.MostDangerousBee(); mvp != drone {
+		t.Errorf("Expected the fled Drone to stay in the danger report, got %v", mvp)
+	}
+	var out strings.Builder
+	game.Out = &out
+	game.InspectBee(drone.ID)
+	if want := fmt.Sprintf("(#%d) has fled the fight with 5 HP and returns on turn 3", drone.ID); !strings.Contains(out.String(), want) {
+		t.Errorf("Expected %q when inspecting the fled Drone, got: %s", want, out.String())
+	}
+	game.Out = io.Discard
+
+	// Stop further flights so only the return is observed
+	game.Config.FleeChance = 0
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if isAlive() {
+		t.Fatal("Expected the Drone to still be away on turn 2")
+	}
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if !isAlive() || len(game.Fled) != 0 {
+		t.Fatalf("Expected the Drone to rejoin AliveBees on turn 3, %d bees still fled", len(game.Fled))
+	}
+	if drone.HP != 5 {
+		t.Errorf("Expected the Drone to return with its 5 HP, has %d", drone.HP)
+	}
+
+	// Clearing the hive while a bee is still away wins, the absent bee forfeiting
+	game.Config.FleeChance = 1
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if len(game.Fled) != 1 {
+		t.Fatalf("Expected the Drone to flee again, %d bees fled", len(game.Fled))
+	}
+	game.KillAllBees()
+	if result := game.Result(); !result.Over || !result.PlayerWon {
+		t.Errorf("Expected clearing the hive to win with a bee still away, got %+v", result)
+	}
+
+	for _, chance := range []float64{-0.1, 1.5} {
+		config.FleeChance = chance
+		if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "flee chance") {
+			t.Errorf("Expected flee chance %g to be rejected, got %v", chance, err)
+		}
+	}
+}
+
+// Test a two-round boss rush summons a tougher Queen once the first hive is cleared
+func TestBossRushSpawnsTougherQueen(t *testing.T) {
+	config := DefaultConfig()
+	config.BossRush = true
+	config.BossRushRounds = 2
+	config.WorkerCount = 0
+	config.DroneCount = 0
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 1
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	first := game.GetBeesByType(Queen)[0]
+	first.HP = 1
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	if game.IsGameOver() || game.Round != 2 {
+		t.Fatalf("Expected round 2 to start after clearing the hive, round %d, game over %v", game.Round, game.IsGameOver())
+	}
+	queens := game.GetBeesByType(Queen)
+	if len(queens) != 1 || queens[0] == first {
+		t.Fatalf("Expected a fresh Queen for round 2, got %d living Queens", len(queens))
+	}
+	boss := queens[0]
+	if boss.MaxHP <= QueenHP || boss.HP != boss.MaxHP || boss.Damage <= QueenDamage {
+		t.Errorf("Expected a tougher Queen than %d HP/%d damage, got %d/%d HP and %d damage", QueenHP, QueenDamage, boss.HP, boss.MaxHP, boss.Damage)
+	}
+
+	// Clearing the last round wins the game
+	boss.HP = 1
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if !game.Result().PlayerWon || game.Round != 2 {
+		t.Fatalf("Expected victory after the final round, round %d, result %+v", game.Round, game.Result())
+	}
+
+	var buf bytes.Buffer
+	game.Out = &buf
+	game.EndGame()
+	if !strings.Contains(buf.String(), "Boss rush rounds survived: 2/2") {
+		t.Errorf("Expected EndGame to report both rounds survived, got: %s", buf.String())
+	}
+}
+
+// Test MaxBeeHitsPerTurn lets that many of the deciding bees land in one bee turn
+func TestBeeTurnMaxBeeHitsPerTurn(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 0
+	config.DroneCount = 5
+	config.BeesMissChance = 0 // Every bee decides to hit
+	config.MaxBeeHitsPerTurn = 2
+	config.BeeSeed = 11
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	game.BeeTurn()
+
+	stings := 0
+	for _, record := range game.Transcript() {
+		if record.Action == EventBeeSting {
+			stings++
+		}
+	}
+	if stings != 2 {
+		t.Errorf("Expected exactly 2 stings to land, got %d", stings)
+	}
+	if want := config.PlayerHP - 2*DroneDamage; game.Player.HP != want {
+		t.Errorf("Expected both stings to be applied leaving %d HP, got %d", want, game.Player.HP)
+	}
+
+	// A sting that costs the player a life ends the volley, so the respawned player starts fresh
+	config.PlayerHP = DroneDamage
+	config.Lives = 2
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.BeeTurn()
+	if game.LivesLeft != 1 || game.Player.HP != config.PlayerHP {
+		t.Errorf("Expected a respawn at full %d HP untouched by the second sting, got %d HP and %d lives",
+			config.PlayerHP, game.Player.HP, game.LivesLeft)
+	}
+}
+
+// Test GetBeesByType returns the living bees in ascending ID order, however the hive stores them
+func TestGetBeesByTypeSortedByID(t *testing.T) {
+	game := NewGame()
+	drones := game.GetBeesByType(Drone)
+	for _, i := range []int{3, 4, 10, 17} {
+		drones[i].HP = 0
+	}
+	// Scramble the stored order, as bees rejoining after fleeing would
+	hive := game.Hive[Drone]
+	for i, j := 0, len(hive)-1; i < j; i, j = i+1, j-1 {
+		hive[i], hive[j] = hive[j], hive[i]
+	}
+
+	alive := game.GetBeesByType(Drone)
+	if len(alive) != len(drones)-4 {
+		t.Fatalf("Expected %d living Drones, got %d", len(drones)-4, len(alive))
+	}
+	for i := 1; i < len(alive); i++ {
+		if alive[i-1].ID >= alive[i].ID {
+			t.Fatalf("Expected ascending IDs, got %d before %d", alive[i-1].ID, alive[i].ID)
+		}
+	}
+}
+
+// Test a healer Drone mends a wounded Worker instead of stinging
+func TestBeeTurnHealerDrone(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 1
+	config.DroneCount = 1
+	config.HealerDrones = 1
+	config.BeesMissChance = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	drone := game.GetBeesByType(Drone)[0]
+	if !drone.Healer {
+		t.Fatal("Expected the Drone to be a healer")
+	}
+	worker := game.GetBeesByType(Worker)[0]
+	worker.HP = worker.MaxHP - 25
+
+	game.BeeTurn()
+
+	if want := worker.MaxHP - 25 + HealerDroneHealing; worker.HP != want {
+		t.Errorf("Expected the Worker healed to %d HP, got %d", want, worker.HP)
+	}
+	// Only the Worker stings
+	if want := 100 - WorkerDamage; game.Player.HP != want {
+		t.Errorf("Expected only the Worker's sting to land leaving %d HP, got %d", want, game.Player.HP)
+	}
+}
@@ -0,0 +1,37 @@
+package game
+
+import "testing"
+
+// Test disabling the monitor skips its channel and bee hits still work
+func TestDisableMonitor(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableMonitor = true
+	game := newSingleBeeGame(config, Worker)
+
+	if game.damageEvent != nil {
+		t.Error("Expected no damage channel when the monitor is disabled")
+	}
+
+	// A landing sting must not block or panic on the missing channel
+	captureStdout(game.BeeTurn)
+	if game.Player.HP != game.Player.MaxHP-WorkerDamage {
+		t.Errorf("Expected the Worker sting to land, player has %d HP", game.Player.HP)
+	}
+}
+
+// Benchmark creating games with the damage monitor running, closing each so monitors don't pile up
+func BenchmarkNewGame(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewGame().Close()
+	}
+}
+
+// Benchmark creating lightweight games without the damage monitor
+func BenchmarkNewGameDisableMonitor(b *testing.B) {
+	config := DefaultConfig()
+	config.DisableMonitor = true
+
+	for i := 0; i < b.N; i++ {
+		NewGameWithConfig(config).Close()
+	}
+}
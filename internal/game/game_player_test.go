@@ -0,0 +1,1284 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// primeQueenKill leaves the Queen one hit from death and makes her the only attackable bee
+func primeQueenKill(game *Game) *Bee {
+	queen := game.GetBeesByType(Queen)[0]
+	queen.HP = QueenTakesDamage
+	game.AliveBees = []*Bee{queen}
+	game.Config.PlayerMissChance = 0
+	return queen
+}
+
+// Test the Queen-death wipe can spare Drones for a cleanup phase
+func TestQueenWipeSparesDrones(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenWipeSpares = []BeeType{Drone}
+	game := NewGameWithConfig(config)
+	queen := primeQueenKill(game)
+
+	output := captureStdout(game.PlayerAttack)
+
+	if queen.IsAlive() {
+		t.Fatal("Expected the Queen to die from the final hit")
+	}
+	if !strings.Contains(output, "the Drone bees fight on") {
+		t.Errorf("Expected spared wipe message, got: %s", output)
+	}
+
+	if workers := game.GetBeesByType(Worker); len(workers) != 0 {
+		t.Errorf("Expected all Workers to die in the wipe, got %d alive", len(workers))
+	}
+	if drones := game.GetBeesByType(Drone); len(drones) != DefaultDroneCount {
+		t.Errorf("Expected all %d Drones to be spared, got %d", DefaultDroneCount, len(drones))
+	}
+	if len(game.GetAliveBees()) != DefaultDroneCount {
+		t.Errorf("Expected alive list to contain only the spared Drones, got %d bees", len(game.GetAliveBees()))
+	}
+	if game.IsGameOver() {
+		t.Error("Game should continue while spared Drones are alive")
+	}
+}
+
+// Test disabling the Queen wipe leaves the rest of the hive fighting
+func TestQueenWipeDisabled(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableQueenWipe = true
+	config.PlayerMissChance = 0
+	game := NewGameWithConfig(config)
+	queen := game.GetBeesByType(Queen)[0]
+	queen.HP = 1 // Weakest bee, so 'finish' takes her out
+
+	output := captureStdout(game.FinishAttack)
+
+	if queen.IsAlive() {
+		t.Fatal("Expected the Queen to die from the finishing blow")
+	}
+	if !strings.Contains(output, "the remaining bees fight on") {
+		t.Errorf("Expected leaderless hive message, got: %s", output)
+	}
+	if strings.Contains(output, "flee in terror") {
+		t.Errorf("Did not expect the wipe message with the wipe disabled, got: %s", output)
+	}
+
+	expectedAlive := DefaultWorkerCount + DefaultDroneCount
+	if alive := len(game.GetAliveBees()); alive != expectedAlive {
+		t.Errorf("Expected %d bees to survive the Queen, got %d", expectedAlive, alive)
+	}
+	if game.IsGameOver() {
+		t.Error("Game should continue after the Queen dies with the wipe disabled")
+	}
+}
+
+// Test weighted targeting heavily favours the weighted bee type
+func TestSelectTargetWeighted(t *testing.T) {
+	config := DefaultConfig()
+	config.TargetWeights = map[BeeType]float64{Queen: 0.01, Worker: 0.01, Drone: 100}
+	game := NewGameWithConfig(config)
+	game.rng = rand.New(rand.NewSource(42))
+
+	aliveBees := game.GetAliveBees()
+	counts := make(map[BeeType]int)
+	for i := 0; i < 1000; i++ {
+		counts[game.selectTarget(aliveBees).Type]++
+	}
+
+	if counts[Drone] < 990 {
+		t.Errorf("Expected Drones to be chosen overwhelmingly, got %v", counts)
+	}
+}
+
+// Test targeting falls back to uniform selection without usable weights
+func TestSelectTargetUniformFallback(t *testing.T) {
+	for _, weights := range []map[BeeType]float64{nil, {Queen: 0, Worker: 0, Drone: 0}} {
+		config := DefaultConfig()
+		config.TargetWeights = weights
+		game := NewGameWithConfig(config)
+		game.rng = rand.New(rand.NewSource(42))
+
+		aliveBees := game.GetAliveBees()
+		counts := make(map[BeeType]int)
+		for i := 0; i < 1000; i++ {
+			counts[game.selectTarget(aliveBees).Type]++
+		}
+
+		// 25 of 31 bees are Drones, so uniform picks land on other types regularly
+		if counts[Worker] == 0 || counts[Drone] == 0 {
+			t.Errorf("Expected uniform selection across types with weights %v, got %v", weights, counts)
+		}
+	}
+}
+
+// queenSelector is a custom strategy that always targets the Queen
+type queenSelector struct{}
+
+func (queenSelector) Select(bees []*Bee, _ Randomizer) *Bee {
+	for _, bee := range bees {
+		if bee.Type == Queen {
+			return bee
+		}
+	}
+	return bees[0]
+}
+
+// Test an injected TargetSelector decides which bee a landed hit strikes
+func TestCustomTargetSelector(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	config.DisableQueenWipe = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.TargetSelector = queenSelector{}
+
+	game.PlayerAttack()
+
+	queen := game.Hive[Queen][0]
+	if queen.HP != QueenHP-QueenTakesDamage {
+		t.Errorf("Expected the Queen to be hit down to %d HP, got %d", QueenHP-QueenTakesDamage, queen.HP)
+	}
+	for _, bee := range append(game.Hive[Worker], game.Hive[Drone]...) {
+		if bee.HP != bee.MaxHP {
+			t.Errorf("Expected only the Queen to be hit, %s #%d has %d HP", bee.Type, bee.ID, bee.HP)
+		}
+	}
+}
+
+// Test passive regeneration heals each player turn without exceeding max HP
+func TestPassiveRegen(t *testing.T) {
+	config := DefaultConfig()
+	config.PassiveRegen = 4
+	game := NewGameWithConfig(config)
+	game.Player.HP = 90
+
+	output := captureStdout(func() { game.PlayerTurn("") })
+	if game.Player.HP != 94 {
+		t.Errorf("Expected regen to heal to 94 HP, got %d", game.Player.HP)
+	}
+	if !strings.Contains(output, "You regenerate 4 HP (94/100)") {
+		t.Errorf("Expected regen message, got: %s", output)
+	}
+
+	// Two more turns would overshoot, so the second is capped at max HP
+	captureStdout(func() { game.PlayerTurn("") })
+	output = captureStdout(func() { game.PlayerTurn("") })
+	if game.Player.HP != game.Player.MaxHP {
+		t.Errorf("Expected regen to stop at %d HP, got %d", game.Player.MaxHP, game.Player.HP)
+	}
+	if !strings.Contains(output, "You regenerate 2 HP (100/100)") {
+		t.Errorf("Expected capped regen message, got: %s", output)
+	}
+
+	// At full health there's nothing to regenerate
+	output = captureStdout(func() { game.PlayerTurn("") })
+	if strings.Contains(output, "regenerate") {
+		t.Errorf("Expected no regen message at full health, got: %s", output)
+	}
+}
+
+// Test regeneration stops once MaxTotalHealing has been used up
+func TestMaxTotalHealing(t *testing.T) {
+	config := DefaultConfig()
+	config.PassiveRegen = 4
+	config.MaxTotalHealing = 10
+	game := NewGameWithConfig(config)
+	game.Player.HP = 50
+
+	// 4 + 4 + the last 2 of the cap
+	for i := 0; i < 3; i++ {
+		captureStdout(func() { game.PlayerTurn("") })
+	}
+	if game.Player.HP != 60 || game.TotalHealed != 10 {
+		t.Fatalf("Expected the cap to stop healing at 60 HP after 10 healed, got %d HP after %d", game.Player.HP, game.TotalHealed)
+	}
+
+	output := captureStdout(func() { game.PlayerTurn("") })
+	if game.Player.HP != 60 {
+		t.Errorf("Expected no healing past the cap, got %d HP", game.Player.HP)
+	}
+	if !strings.Contains(output, "Your healing is exhausted") {
+		t.Errorf("Expected a message refusing the heal, got: %s", output)
+	}
+	healed := 0
+	captureStdout(func() { healed = game.healPlayer(15) })
+	if healed != 0 {
+		t.Errorf("Expected lifesteal-sized heals to be refused too, healed %d", healed)
+	}
+}
+
+// countPlayerAttacks plays one player turn and counts the attacks it resolved
+func countPlayerAttacks(game *Game) int {
+	events, unsubscribe := game.Events()
+	defer unsubscribe()
+
+	captureStdout(func() { game.PlayerTurn("hit") })
+
+	attacks := 0
+	for {
+		select {
+		case event := <-events:
+			switch event.Action {
+			case EventPlayerHit, EventBeeKilled, EventPlayerMiss:
+				attacks++
+			}
+		default:
+			return attacks
+		}
+	}
+}
+
+// Test multiple attacks are resolved in a single player turn
+func TestAttacksPerTurn(t *testing.T) {
+	config := DefaultConfig()
+	config.AttacksPerTurn = 3
+	config.PlayerMissChance = 0
+	game := NewGameWithConfig(config)
+
+	if attacks := countPlayerAttacks(game); attacks != 3 {
+		t.Errorf("Expected 3 attacks in one turn, got %d", attacks)
+	}
+	if game.Turns != 1 {
+		t.Errorf("Expected all attacks to happen in a single turn, got %d turns", game.Turns)
+	}
+}
+
+// Test the attack sequence stops once the Queen wipe clears the hive
+func TestAttacksPerTurnStopsAfterQueenWipe(t *testing.T) {
+	config := DefaultConfig()
+	config.AttacksPerTurn = 3
+	game := NewGameWithConfig(config)
+	primeQueenKill(game)
+
+	if attacks := countPlayerAttacks(game); attacks != 1 {
+		t.Errorf("Expected attacks to stop after the Queen wipe, got %d", attacks)
+	}
+}
+
+// Test lifesteal heals the player on a kill but not on a non-lethal hit
+func TestLifestealOnKill(t *testing.T) {
+	config := DefaultConfig()
+	config.Lifesteal = 15
+	config.PlayerMissChance = 0
+	game := newSingleBeeGame(config, Worker)
+	game.Player.HP = 50
+	worker := game.GetBeesByType(Worker)[0]
+
+	// First hit leaves the Worker alive, so no healing
+	output := captureStdout(game.PlayerAttack)
+	if !worker.IsAlive() {
+		t.Fatal("Expected the Worker to survive the first hit")
+	}
+	if game.Player.HP != 50 {
+		t.Errorf("Expected no lifesteal on a non-lethal hit, player has %d HP", game.Player.HP)
+	}
+	if strings.Contains(output, "absorb") {
+		t.Errorf("Did not expect lifesteal message on a non-lethal hit, got: %s", output)
+	}
+
+	// Bring the Worker to the brink and finish it off
+	worker.HP = WorkerTakesDamage
+	output = captureStdout(game.PlayerAttack)
+	if worker.IsAlive() {
+		t.Fatal("Expected the Worker to die")
+	}
+	if game.Player.HP != 65 {
+		t.Errorf("Expected lifesteal to heal to 65 HP, got %d", game.Player.HP)
+	}
+	if !strings.Contains(output, "You absorb 15 HP from the kill!") {
+		t.Errorf("Expected lifesteal message, got: %s", output)
+	}
+}
+
+// Test a dropped shield negates the next sting and is used up
+func TestShieldDropAbsorbsSting(t *testing.T) {
+	config := DefaultConfig()
+	config.ItemDropChance = 1.0
+	config.PlayerMissChance = 0
+	config.PlayerSeed = 3
+	game := newSingleBeeGame(config, Drone)
+
+	// Add a Worker to sting back after the Drone is killed
+	worker := game.addBee(Worker)
+	drone := game.GetBeesByType(Drone)[0]
+	drone.HP = DroneTakesDamage
+	game.AliveBees = []*Bee{drone}
+
+	output := captureStdout(game.PlayerAttack)
+	if !game.HasShield {
+		t.Fatalf("Expected a guaranteed shield drop on kill, got: %s", output)
+	}
+	if !strings.Contains(output, "You picked up a shield!") {
+		t.Errorf("Expected pickup message, got: %s", output)
+	}
+
+	// The Worker's sting is absorbed completely
+	game.AliveBees = []*Bee{worker}
+	output = captureStdout(game.BeeTurn)
+	if game.Player.HP != game.Player.MaxHP {
+		t.Errorf("Expected the shield to absorb the sting, player has %d HP", game.Player.HP)
+	}
+	if game.HasShield {
+		t.Error("Expected the shield to be consumed")
+	}
+	if !strings.Contains(output, "Your shield absorbs the sting and shatters!") {
+		t.Errorf("Expected shield usage message, got: %s", output)
+	}
+
+	// Without a shield the next sting lands
+	captureStdout(game.BeeTurn)
+	if game.Player.HP != game.Player.MaxHP-WorkerDamage {
+		t.Errorf("Expected the next sting to land for %d damage, player has %d HP", WorkerDamage, game.Player.HP)
+	}
+}
+
+// Test 'finish' always goes for the weakest bee, breaking ties by ID
+func TestFinishTargetsWeakestBee(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	game := NewGameWithConfig(config)
+
+	drones := game.GetBeesByType(Drone)
+	workers := game.GetBeesByType(Worker)
+	workers[2].HP = 20 // Weakest
+	drones[4].HP = 20  // Tied, but with a higher ID
+	drones[1].HP = 30
+
+	captureStdout(func() { game.PlayerTurn("finish") })
+	if workers[2].IsAlive() {
+		t.Errorf("Expected finish to kill the weakest bee (Worker #%d) first", workers[2].ID)
+	}
+	if drones[4].HP != 20 {
+		t.Errorf("Expected the tied Drone with a higher ID to be untouched, has %d HP", drones[4].HP)
+	}
+
+	captureStdout(func() { game.PlayerTurn("finish") })
+	if drones[4].IsAlive() {
+		t.Error("Expected the next finish to kill the remaining 20 HP Drone")
+	}
+
+	captureStdout(func() { game.PlayerTurn("finish") })
+	if drones[1].IsAlive() {
+		t.Error("Expected the next finish to kill the 30 HP Drone")
+	}
+}
+
+// Test bee stings fill the rage meter and 'special' damages every living bee
+func TestRageMeterSpecialAttack(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 3
+	config.DroneCount = 0
+	config.BeesMissChance = 0
+	config.RageMeterMax = 10
+	config.SpecialDamage = 7
+	game := NewGameWithConfig(config)
+
+	if err := game.Step("special"); !errors.Is(err, ErrSpecialNotReady) {
+		t.Fatalf("Expected ErrSpecialNotReady with an empty meter, got %v", err)
+	}
+	if game.Turns != 0 {
+		t.Errorf("A rejected special should not consume a turn, got %d turns", game.Turns)
+	}
+
+	// Every Worker sting lands, so the meter fills within a couple of bee turns
+	var output string
+	for i := 0; i < 5 && !game.SpecialReady(); i++ {
+		output += captureStdout(game.BeeTurn)
+	}
+	if !game.SpecialReady() {
+		t.Fatalf("Expected the rage meter to fill from bee stings, rage is %d", game.Rage)
+	}
+	if game.Rage != config.RageMeterMax {
+		t.Errorf("Expected rage to cap at %d, got %d", config.RageMeterMax, game.Rage)
+	}
+	if !strings.Contains(output, "rage meter is full") {
+		t.Errorf("Expected a full meter announcement, got: %s", output)
+	}
+
+	output = captureStdout(func() { game.PlayerTurn("special") })
+
+	for _, worker := range game.GetBeesByType(Worker) {
+		if worker.HP != worker.MaxHP-config.SpecialDamage {
+			t.Errorf("Expected Worker #%d to take %d special damage, has %d/%d HP", worker.ID, config.SpecialDamage, worker.HP, worker.MaxHP)
+		}
+	}
+	if game.Rage != 0 || game.SpecialReady() {
+		t.Errorf("Expected the special attack to empty the meter, rage is %d", game.Rage)
+	}
+	if !strings.Contains(output, "RAGE UNLEASHED") {
+		t.Errorf("Expected special attack message, got: %s", output)
+	}
+}
+
+// Test special attack and cleave kills earn the same lifesteal and morale as a direct kill
+func TestAreaKillsAreRewarded(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 2
+	config.DroneCount = 0
+	config.Lifesteal = 10
+	config.Morale = true
+	config.RageMeterMax = 10
+	config.CleaveCharges = 1
+	config.DisableMonitor = true
+
+	for _, command := range []string{"special", "cleave workers"} {
+		game := NewGameWithConfig(config)
+		game.Out = io.Discard
+		game.Player.HP = 50
+		game.Morale = 50
+		game.Rage = config.RageMeterMax
+		for _, worker := range game.GetBeesByType(Worker) {
+			worker.HP = 1
+		}
+
+		game.PlayerTurn(command)
+
+		if alive := len(game.GetAliveBees()); alive != 0 {
+			t.Fatalf("%s: expected both Workers to die, %d bees alive", command, alive)
+		}
+		if game.Player.HP != 70 {
+			t.Errorf("%s: expected lifesteal from both kills to heal to 70 HP, got %d", command, game.Player.HP)
+		}
+		if want := 50 + 2*MoraleGainPerKill; game.Morale != want {
+			t.Errorf("%s: expected both kills to lift morale to %d, got %d", command, want, game.Morale)
+		}
+	}
+}
+
+// Test the berserker build kills faster but takes more damage than a baseline game with the same seed
+func TestBerserkerTradesDefenseForOffense(t *testing.T) {
+	play := func(berserker bool) (int, int) {
+		config := DefaultConfig()
+		config.Berserker = berserker
+		config.PlayerMissChance = 0
+		config.PlayerSeed = 3
+		config.BeeSeed = 3
+		game := newSingleBeeGame(config, Queen)
+
+		captureStdout(game.BeeTurn)
+		damageTaken := game.Player.MaxHP - game.Player.HP
+
+		attacks := 0
+		for !game.IsGameOver() {
+			captureStdout(game.PlayerAttack)
+			attacks++
+		}
+		return attacks, damageTaken
+	}
+
+	baseAttacks, baseDamage := play(false)
+	berserkAttacks, berserkDamage := play(true)
+
+	if baseAttacks != QueenHP/QueenTakesDamage {
+		t.Errorf("Expected the baseline to need %d hits on the Queen, got %d", QueenHP/QueenTakesDamage, baseAttacks)
+	}
+	if berserkAttacks >= baseAttacks {
+		t.Errorf("Expected a berserker to kill the Queen in fewer than %d hits, took %d", baseAttacks, berserkAttacks)
+	}
+	if baseDamage != QueenDamage || berserkDamage != QueenDamage*3/2 {
+		t.Errorf("Expected Queen stings of %d (baseline) and %d (berserker), got %d and %d",
+			QueenDamage, QueenDamage*3/2, baseDamage, berserkDamage)
+	}
+}
+
+// Test the berserker bonus also powers up the special attack and splash damage
+func TestBerserkerScalesAreaDamage(t *testing.T) {
+	config := DefaultConfig()
+	config.Berserker = true
+	config.QueenCount = 0
+	config.WorkerCount = 3
+	config.DroneCount = 0
+	config.RageMeterMax = 10
+	config.SpecialDamage = 10
+	config.SplashDamage = 4
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	workers := game.GetBeesByType(Worker)
+
+	game.Rage = config.RageMeterMax
+	game.SpecialAttack()
+	for _, worker := range workers {
+		if worker.HP != WorkerHP-15 {
+			t.Errorf("Expected Worker #%d to take 15 berserker special damage, has %d HP", worker.ID, worker.HP)
+		}
+	}
+
+	game.splash(workers[:1])
+	if workers[0].HP != WorkerHP-15-6 {
+		t.Errorf("Expected Worker #%d to take 6 berserker splash damage, has %d HP", workers[0].ID, workers[0].HP)
+	}
+}
+
+// Test HitsToClear finds the quickest route through a damaged hive
+func TestHitsToClear(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 1
+	config.WorkerCount = 2
+	config.DroneCount = 1
+	game := NewGameWithConfig(config)
+
+	queen := game.GetBeesByType(Queen)[0]
+	workers := game.GetBeesByType(Worker)
+	drone := game.GetBeesByType(Drone)[0]
+	queen.HP = 35      // ceil(35/10) = 4 hits
+	workers[0].HP = 75 // ceil(75/25) = 3 hits
+	workers[1].HP = 26 // ceil(26/25) = 2 hits
+	drone.HP = 30      // ceil(30/30) = 1 hit
+
+	// With the wipe the Queen alone clears the hive
+	if hits := game.HitsToClear(); hits != 4 {
+		t.Errorf("Expected 4 hits via the Queen wipe, got %d", hits)
+	}
+
+	// A shielded Queen can't be reached until both Workers are dead
+	game.Config.QueenShielded = true
+	if hits := game.HitsToClear(); hits != 3+2+4 {
+		t.Errorf("Expected 9 hits through the shielding Workers, got %d", hits)
+	}
+	game.Config.QueenShielded = false
+
+	// Spared Drones still need killing after the Queen
+	game.Config.QueenWipeSpares = []BeeType{Drone}
+	if hits := game.HitsToClear(); hits != 4+1 {
+		t.Errorf("Expected 5 hits with spared Drones, got %d", hits)
+	}
+
+	// Without the wipe every bee has to be killed individually
+	game.Config.DisableQueenWipe = true
+	if hits := game.HitsToClear(); hits != 4+3+2+1 {
+		t.Errorf("Expected 10 hits without the wipe, got %d", hits)
+	}
+
+	// Sparing every other type means the Queen route still has to mop them all up
+	queen.HP = 100 // 10 hits
+	workers[0].HP = 1
+	workers[1].HP = 1
+	drone.HP = 1
+	game.Config.DisableQueenWipe = false
+	game.Config.QueenWipeSpares = []BeeType{Worker, Drone}
+	if hits := game.HitsToClear(); hits != 10+3 {
+		t.Errorf("Expected 13 hits when the wipe spares everyone else, got %d", hits)
+	}
+	queen.HP = 0
+	if hits := game.HitsToClear(); hits != 3 {
+		t.Errorf("Expected 3 hits to finish the leaderless hive, got %d", hits)
+	}
+}
+
+// Test a slash weapon deals bonus damage to slash-weak Drones and reduced damage to resistant Queens
+func TestWeaponResistances(t *testing.T) {
+	tests := []struct {
+		name           string
+		beeType        BeeType
+		expectedDamage int
+		expectedText   string
+	}{
+		{"Drone weak to slash", Drone, 45, "weak to slash"}, // 30 x 1.5
+		{"Queen resists slash", Queen, 8, "resists slash"},  // 10 x 0.75 rounded
+		{"Worker unaffected", Worker, WorkerTakesDamage, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.WeaponType = DamageSlash
+			config.PlayerMissChance = 0
+			game := newSingleBeeGame(config, test.beeType)
+			bee := game.GetAliveBees()[0]
+
+			output := captureStdout(game.PlayerAttack)
+
+			if dealt := bee.MaxHP - bee.HP; dealt != test.expectedDamage {
+				t.Errorf("Expected %s to take %d slash damage, took %d", test.beeType, test.expectedDamage, dealt)
+			}
+			if test.expectedText != "" && !strings.Contains(output, test.expectedText) {
+				t.Errorf("Expected %q in output, got: %s", test.expectedText, output)
+			}
+		})
+	}
+
+	// Without a weapon type resistances are ignored
+	game := NewGame()
+	if damage := game.getDamageDealtTo(Drone); damage != DroneTakesDamage {
+		t.Errorf("Expected plain Drone damage %d without a weapon type, got %d", DroneTakesDamage, damage)
+	}
+}
+
+// Test cleaving Drones hits every living Drone once and leaves other types alone
+func TestCleaveDrones(t *testing.T) {
+	config := DefaultConfig()
+	config.CleaveCharges = 1
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	drones := game.GetBeesByType(Drone)
+	drones[0].HP = DroneTakesDamage // One cleave finishes this one off
+
+	if err := game.Step("cleave drones"); err != nil {
+		t.Fatalf("Expected cleave to be accepted, got %v", err)
+	}
+
+	if drones[0].IsAlive() {
+		t.Error("Expected the wounded Drone to die from the cleave")
+	}
+	for _, drone := range drones[1:] {
+		if drone.HP != DroneHP-DroneTakesDamage {
+			t.Errorf("Expected Drone #%d to take %d cleave damage, has %d HP", drone.ID, DroneTakesDamage, drone.HP)
+		}
+	}
+	for _, beeType := range []BeeType{Queen, Worker} {
+		for _, bee := range game.GetBeesByType(beeType) {
+			if bee.HP != bee.MaxHP {
+				t.Errorf("Expected %s #%d to be untouched, has %d/%d HP", beeType, bee.ID, bee.HP, bee.MaxHP)
+			}
+		}
+	}
+
+	// The only charge is spent, and bad types are rejected
+	if err := game.Step("cleave drone"); !errors.Is(err, ErrNoCleaves) {
+		t.Errorf("Expected ErrNoCleaves once charges run out, got %v", err)
+	}
+	game.CleavesLeft = 1
+	if err := game.Step("cleave wasps"); err == nil {
+		t.Error("Expected an unknown bee type to be rejected")
+	}
+}
+
+// Test cleaving the Queen to death still triggers the hive wipe
+func TestCleaveQueenWipe(t *testing.T) {
+	config := DefaultConfig()
+	config.CleaveCharges = 1
+	game := NewGameWithConfig(config)
+	game.GetBeesByType(Queen)[0].HP = 1
+
+	captureStdout(func() { game.PlayerTurn("cleave queen") })
+
+	if !game.IsGameOver() || len(game.GetAliveBees()) != 0 {
+		t.Errorf("Expected the Queen wipe after cleaving her, %d bees alive", len(game.GetAliveBees()))
+	}
+}
+
+// Test attacks are blocked once stamina runs out until the player rests
+func TestStaminaBlocksAttacksUntilRest(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxStamina = 2
+	config.PlayerMissChance = 0
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	for i := 0; i < 2; i++ {
+		game.PlayerTurn("hit")
+	}
+	if game.Stamina != 0 || game.HasStamina() {
+		t.Fatalf("Expected two hits to drain 2 stamina, have %d", game.Stamina)
+	}
+	if err := game.Step("hit"); !errors.Is(err, ErrNoStamina) {
+		t.Errorf("Expected ErrNoStamina when exhausted, got %v", err)
+	}
+
+	// An exhausted attack does nothing to the hive
+	hpBefore := make(map[int]int)
+	for _, bee := range game.GetAliveBees() {
+		hpBefore[bee.ID] = bee.HP
+	}
+	output := captureStdout(func() {
+		game.Out = nil
+		game.PlayerTurn("hit")
+	})
+	if !strings.Contains(output, "too exhausted to attack") {
+		t.Errorf("Expected an exhaustion message, got: %s", output)
+	}
+	for _, bee := range game.GetAliveBees() {
+		if bee.HP != hpBefore[bee.ID] {
+			t.Errorf("Expected bee #%d to be untouched while exhausted", bee.ID)
+		}
+	}
+
+	// Resting refills the pool and attacks work again
+	game.Out = io.Discard
+	game.PlayerTurn("rest")
+	if game.Stamina != 2 || !game.HasStamina() {
+		t.Errorf("Expected rest to refill stamina to 2, have %d", game.Stamina)
+	}
+	game.PlayerTurn("hit")
+	if game.Stamina != 1 {
+		t.Errorf("Expected a hit after resting to cost 1 stamina, have %d", game.Stamina)
+	}
+}
+
+// Test 'skip' spends a turn on the bees without touching the hive, while bad input spends nothing
+func TestSkipTurn(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.In = strings.NewReader("dance\nskip\nquit\n")
+	var out bytes.Buffer
+	game.Out = &out
+
+	game.PlayGame()
+
+	if game.Turns != 1 {
+		t.Errorf("Expected only 'skip' to use a turn, %d turns played", game.Turns)
+	}
+	if !strings.Contains(out.String(), "Bees Turn") {
+		t.Errorf("Expected the bees to take their turn, got: %s", out.String())
+	}
+	for _, bee := range game.GetAliveBees() {
+		if bee.HP != bee.MaxHP {
+			t.Errorf("Expected bee #%d untouched after skipping, has %d/%d HP", bee.ID, bee.HP, bee.MaxHP)
+		}
+	}
+}
+
+// Test a hit splashes onto the nearest bees of the target's type, and a splash kill on a Queen wipes the hive
+func TestSplashDamage(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 5
+	config.DroneCount = 0
+	config.PlayerMissChance = 0
+	config.SplashDamage = 3
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	workers := game.GetBeesByType(Worker)
+	game.AimAttack(workers[2].ID)
+
+	want := []int{WorkerHP, WorkerHP - 3, WorkerHP - game.getDamageDealtTo(Worker), WorkerHP - 3, WorkerHP}
+	for i, worker := range workers {
+		if worker.HP != want[i] {
+			t.Errorf("Expected Worker #%d at %d HP, got %d", worker.ID, want[i], worker.HP)
+		}
+	}
+
+	// Two Queens: splashing the wounded one to death brings the hive down
+	config.QueenCount = 2
+	config.WorkerCount = 0
+	config.DroneCount = 3
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	queens := game.GetBeesByType(Queen)
+	queens[1].HP = 1
+	game.AimAttack(queens[0].ID)
+
+	if alive := len(game.GetAliveBees()); alive != 0 {
+		t.Errorf("Expected the splash kill on a Queen to wipe the hive, %d bees alive", alive)
+	}
+
+	// A splash kill is rewarded like any other kill
+	config.QueenCount = 0
+	config.WorkerCount = 2
+	config.Lifesteal = 5
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.HP = 50
+	workers = game.GetBeesByType(Worker)
+	workers[1].HP = 1
+	game.AimAttack(workers[0].ID)
+	if workers[1].IsAlive() || game.Player.HP != 55 {
+		t.Errorf("Expected the splash kill to heal 5 HP by lifesteal, player has %d HP", game.Player.HP)
+	}
+
+	// A shielded Queen is safe from splash while her Workers live
+	config.QueenCount = 2
+	config.WorkerCount = 1
+	config.Lifesteal = 0
+	config.QueenShielded = true
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	queens = game.GetBeesByType(Queen)
+	game.splash([]*Bee{queens[1]})
+	if queens[1].HP != QueenHP {
+		t.Errorf("Expected the shielded Queen to shrug off the splash, she has %d HP", queens[1].HP)
+	}
+}
+
+// Test a spare life respawns the player at full HP once, and the next death ends the game
+func TestLivesRespawnPlayer(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerHP = QueenDamage
+	config.Lives = 2
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := newSingleBeeGame(config, Queen)
+	var out bytes.Buffer
+	game.Out = &out
+
+	game.BeeTurn()
+
+	if game.Player.HP != config.PlayerHP || game.LivesLeft != 1 {
+		t.Fatalf("Expected a respawn at %d HP with 1 life left, got %d HP and %d lives", config.PlayerHP, game.Player.HP, game.LivesLeft)
+	}
+	if game.IsGameOver() {
+		t.Fatal("Expected the game to go on after respawning")
+	}
+
+	game.BeeTurn()
+	if !game.IsGameOver() || game.Player.IsAlive() {
+		t.Fatal("Expected the second death to end the game")
+	}
+
+	game.EndGame()
+	if !strings.Contains(out.String(), "respawn with") || !strings.Contains(out.String(), "Lives used: 2/2") {
+		t.Errorf("Expected the respawn and lives used to be reported, got: %s", out.String())
+	}
+}
+
+// Test sustained stings raise the effective miss chance and kills bring it back down
+func TestMoraleAffectsMissChance(t *testing.T) {
+	config := DefaultConfig()
+	config.Morale = true
+	game := newSingleBeeGame(config, Worker)
+	game.Out = io.Discard
+
+	baseline := game.EffectiveMissChance()
+	if baseline != config.PlayerMissChance {
+		t.Errorf("Expected full morale to leave the miss chance at %.2f, got %.2f", config.PlayerMissChance, baseline)
+	}
+
+	// The lone Worker never misses, so every bee turn lands a sting
+	for i := 0; i < 3; i++ {
+		game.BeeTurn()
+	}
+	if game.Morale != MoraleMax-3*MoraleLossPerSting {
+		t.Errorf("Expected 3 stings to cost %d morale, have %d", 3*MoraleLossPerSting, game.Morale)
+	}
+	shaken := game.EffectiveMissChance()
+	if shaken <= baseline {
+		t.Errorf("Expected stings to raise the miss chance above %.2f, got %.2f", baseline, shaken)
+	}
+
+	// Kills restore morale and steady the player's aim (shaken players can still miss, so keep swinging)
+	worker := game.GetBeesByType(Worker)[0]
+	worker.HP = 1
+	for i := 0; i < 50 && worker.IsAlive(); i++ {
+		game.PlayerAttack()
+	}
+	if game.Morale != MoraleMax-3*MoraleLossPerSting+MoraleGainPerKill {
+		t.Errorf("Expected a kill to restore %d morale, have %d", MoraleGainPerKill, game.Morale)
+	}
+	if recovered := game.EffectiveMissChance(); recovered >= shaken {
+		t.Errorf("Expected the kill to lower the miss chance below %.2f, got %.2f", shaken, recovered)
+	}
+}
+
+// Test a guaranteed revive saves the player from the first fatal sting but not the second
+func TestReviveOnce(t *testing.T) {
+	config := DefaultConfig()
+	config.ReviveChance = 1
+	config.ReviveHP = 25
+	config.PlayerSeed = 1
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	queen := game.Hive[Queen][0]
+
+	game.Player.HP = QueenDamage
+	game.stingPlayer(queen, false)
+	if game.Player.HP != 25 || !game.Revived {
+		t.Fatalf("Expected to revive with 25 HP, got %d HP (revived %v)", game.Player.HP, game.Revived)
+	}
+	if game.IsGameOver() {
+		t.Error("Expected the game to continue after a revive")
+	}
+
+	game.Player.HP = QueenDamage
+	game.stingPlayer(queen, false)
+	if game.Player.IsAlive() {
+		t.Errorf("Expected the second fatal sting to kill, player has %d HP", game.Player.HP)
+	}
+}
+
+// Test revives are off by default
+func TestNoReviveByDefault(t *testing.T) {
+	game := NewGame()
+	game.Out = io.Discard
+	game.Player.HP = 1
+	game.stingPlayer(game.Hive[Queen][0], false)
+	if game.Player.IsAlive() || game.Revived {
+		t.Errorf("Expected no revive without ReviveChance, player has %d HP", game.Player.HP)
+	}
+}
+
+// Test damage dealt and taken add up over a short scripted game
+func TestDamageTotals(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 1
+	config.DroneCount = 0
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 0
+	config.DisableThinkDelay = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	// Three hits kill the Worker, which stings back after the first two
+	for !game.IsGameOver() {
+		game.playRound("hit")
+	}
+	if game.DamageDealt != WorkerHP {
+		t.Errorf("Expected %d damage dealt, got %d", WorkerHP, game.DamageDealt)
+	}
+	if game.DamageTaken != 2*WorkerDamage {
+		t.Errorf("Expected %d damage taken, got %d", 2*WorkerDamage, game.DamageTaken)
+	}
+
+	var out strings.Builder
+	game.Out = &out
+	game.EndGame()
+	if want := fmt.Sprintf("You dealt %d damage and took %d.", WorkerHP, 2*WorkerDamage); !strings.Contains(out.String(), want) {
+		t.Errorf("Expected the summary to report %q, got: %s", want, out.String())
+	}
+}
+
+// Test the HP a Queen wipe removes counts towards damage dealt
+func TestDamageTotalsQueenWipe(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	config.QueenCount = 1
+	config.WorkerCount = 2
+	config.DroneCount = 0
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.TargetSelector = queenSelector{}
+	game.Hive[Queen][0].HP = 4
+
+	game.PlayerAttack()
+
+	// Only the 4 HP the Queen had left count for her, then the whole hive falls
+	if want := 4 + 2*WorkerHP; game.DamageDealt != want {
+		t.Errorf("Expected %d damage dealt including the wipe, got %d", want, game.DamageDealt)
+	}
+}
+
+// Test EndGame breaks the damage dealt down by bee type, counting cleaves and the Queen wipe
+func TestDamageByType(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	config.CleaveCharges = 1
+	config.QueenCount = 1
+	config.WorkerCount = 2
+	config.DroneCount = 2
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.TargetSelector = queenSelector{}
+
+	// The cleave kills one wounded Drone and halves the other, then the Queen's death wipes the rest
+	game.Hive[Drone][0].HP = 10
+	game.Cleave(Drone)
+	game.Hive[Queen][0].HP = 4
+	game.PlayerAttack()
+
+	want := map[BeeType]TypeDamage{
+		Queen:  {HPRemoved: 4, Kills: 1},
+		Worker: {HPRemoved: 2 * WorkerHP, Kills: 2},
+		Drone:  {HPRemoved: 10 + DroneHP, Kills: 2},
+	}
+	if got := game.DamageByType(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected per-type damage %+v, got %+v", want, got)
+	}
+
+	var out strings.Builder
+	game.Out = &out
+	game.EndGame()
+	for _, line := range []string{
+		"Queens: 4 HP removed across 1 kill\n",
+		fmt.Sprintf("Workers: %d HP removed across 2 kills", 2*WorkerHP),
+		fmt.Sprintf("Drones: %d HP removed across 2 kills", 10+DroneHP),
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected the summary to report %q, got: %s", line, out.String())
+		}
+	}
+}
+
+// Test gamble's two outcomes with seeds for each: double damage, or a whiff, with the bees answering either way
+func TestGamble(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     int64 // First player roll: seed 2 rolls 0.17 (win), seed 1 rolls 0.60 (lose)
+		workerHP int
+		message  string
+	}{
+		{"double", 2, WorkerHP - 2*WorkerTakesDamage, "DOUBLE!"},
+		{"nothing", 1, WorkerHP, "NOTHING!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.QueenCount = 0
+			config.WorkerCount = 1
+			config.DroneCount = 0
+			config.PlayerSeed = tt.seed
+			config.DisableThinkDelay = true
+			config.DisableMonitor = true // The monitor would write to out concurrently
+			game := NewGameWithConfig(config)
+			var out strings.Builder
+			game.Out = &out
+
+			game.playRound("gamble")
+
+			if hp := game.Hive[Worker][0].HP; hp != tt.workerHP {
+				t.Errorf("Expected the Worker on %d HP, got %d", tt.workerHP, hp)
+			}
+			if !strings.Contains(out.String(), tt.message) {
+				t.Errorf("Expected the outcome %q, got: %s", tt.message, out.String())
+			}
+			if !strings.Contains(out.String(), "Bees Turn") {
+				t.Errorf("Expected the bees to take their turn after a gamble, got: %s", out.String())
+			}
+		})
+	}
+}
+
+// Test a shielded Queen shrugs off hits until every Worker is dead
+func TestQueenShielded(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenShielded = true
+	config.PlayerMissChance = 0
+	config.WorkerCount = 2
+	game := NewGameWithConfig(config)
+	var out strings.Builder
+	game.Out = &out
+	game.TargetSelector = queenSelector{}
+	queen := game.Hive[Queen][0]
+
+	game.PlayerAttack()
+	if queen.HP != QueenHP {
+		t.Errorf("Expected no damage to the Queen while Workers live, she has %d HP", queen.HP)
+	}
+	if !strings.Contains(out.String(), "shielded while her 2 Workers live") {
+		t.Errorf("Expected the shield to be explained, got: %s", out.String())
+	}
+
+	for _, worker := range game.GetBeesByType(Worker) {
+		worker.HP = 0
+	}
+	game.PlayerAttack()
+	if queen.HP != QueenHP-QueenTakesDamage {
+		t.Errorf("Expected the Queen to take damage once the Workers are dead, she has %d HP", queen.HP)
+	}
+}
+
+// Test the shield also turns aside a cleave and the special attack, so neither can wipe the hive early
+func TestQueenShieldedAreaAttacks(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenShielded = true
+	config.WorkerCount = 2
+	config.DroneCount = 0
+	config.CleaveCharges = 1
+	config.RageMeterMax = 10
+	config.SpecialDamage = 5
+	config.DisableMonitor = true
+
+	for _, command := range []string{"cleave queen", "special"} {
+		game := NewGameWithConfig(config)
+		var out strings.Builder
+		game.Out = &out
+		game.Rage = config.RageMeterMax
+		queen := game.Hive[Queen][0]
+		queen.HP = 1 // Any damage at all would kill her
+
+		game.PlayerTurn(command)
+
+		if !queen.IsAlive() || queen.HP != 1 {
+			t.Errorf("%s: expected the shielded Queen to be untouched, she has %d HP", command, queen.HP)
+		}
+		if alive := len(game.GetBeesByType(Worker)); alive != 2 {
+			t.Errorf("%s: expected no hive wipe, %d Workers alive", command, alive)
+		}
+		if !strings.Contains(out.String(), "shielded while her 2 Workers live") {
+			t.Errorf("%s: expected the shield to be explained, got: %s", command, out.String())
+		}
+	}
+}
+
+// Test Step classifies each kind of rejected command with a CommandError
+func TestStepCommandErrors(t *testing.T) {
+	config := DefaultConfig()
+	config.CleaveCharges = 1
+	config.MaxStamina = 1
+	config.QueenCount = 0
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	game.Stamina = 0
+	tests := []struct {
+		command string
+		kind    CommandErrorKind
+		target  error
+	}{
+		{"dance", CommandUnknown, nil},
+		{"cleave", CommandInvalid, nil},
+		{"cleave wasps", CommandInvalid, nil},
+		{"cleave queen", CommandNoTargets, ErrNoBeesOfType},
+		{"hit", CommandUnavailable, ErrNoStamina},
+		{"special", CommandUnavailable, ErrSpecialNotReady},
+	}
+	for _, tt := range tests {
+		err := game.Step(tt.command)
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) {
+			t.Errorf("%q: expected a *CommandError, got %v", tt.command, err)
+			continue
+		}
+		if cmdErr.Kind != tt.kind || cmdErr.Command != tt.command {
+			t.Errorf("%q: expected kind %d, got kind %d for %q", tt.command, tt.kind, cmdErr.Kind, cmdErr.Command)
+		}
+		if tt.target != nil && !errors.Is(err, tt.target) {
+			t.Errorf("%q: expected the error to wrap %v, got %v", tt.command, tt.target, err)
+		}
+	}
+
+	game.CleavesLeft = 0
+	if err := game.Step("cleave drone"); !errors.Is(err, ErrNoCleaves) {
+		t.Errorf("Expected ErrNoCleaves once charges run out, got %v", err)
+	}
+	if game.Turns != 0 {
+		t.Errorf("Expected rejected commands not to play a turn, %d turns played", game.Turns)
+	}
+}
+
+// Test the mercy rule ends a hopeless game after the bee turn, but not one the player can still win
+func TestMercyRule(t *testing.T) {
+	config := DefaultConfig()
+	config.MercyRule = true
+	config.QueenCount = 0 // A Drone swarm stings too softly to kill 5 HP in one turn
+	config.WorkerCount = 0
+	config.BeesMissChance = 0 // Every turn is a certain sting
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	var out strings.Builder
+	game.Out = &out
+	game.Player.HP = 5
+
+	if !game.DefeatInevitable() {
+		t.Fatal("Expected 5 HP against a full swarm that can't miss to be hopeless")
+	}
+	game.BeeTurn()
+	if !game.IsGameOver() || game.Player.HP != 0 {
+		t.Fatalf("Expected the mercy rule to end the game, player has %d HP", game.Player.HP)
+	}
+	if !strings.Contains(out.String(), "defeat is inevitable") {
+		t.Errorf("Expected the mercy rule message, got: %s", out.String())
+	}
+
+	// A spare life is a whole new health bar, so the hive can't finish the player in time
+	config.Lives = 2
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.HP = 5
+	if game.DefeatInevitable() {
+		t.Error("Expected a spare life to keep defeat from being certain")
+	}
+
+	// Once even the spare lives are hopeless, the mercy rule forfeits them instead of respawning
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.MaxHP = 20
+	game.Player.HP = 3
+	if !game.DefeatInevitable() {
+		t.Fatal("Expected two 20 HP lives against a full swarm that can't miss to be hopeless")
+	}
+	game.BeeTurn()
+	if !game.IsGameOver() || game.Player.HP != 0 || game.LivesLeft != 1 {
+		t.Fatalf("Expected the mercy rule to end the game, player has %d HP and %d lives", game.Player.HP, game.LivesLeft)
+	}
+	config.Lives = 1
+
+	// Bees that might miss could let the player survive, so it isn't certain
+	config.BeesMissChance = 1
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.HP = 5
+	if game.DefeatInevitable() {
+		t.Error("Expected a hive that can miss never to make defeat certain")
+	}
+
+	// Stingers dying off, a second player who may pass and blockable stings all keep it uncertain
+	for name, set := range map[string]func(*GameConfig){
+		"workers die on sting": func(c *GameConfig) { c.WorkersDieOnSting = true },
+		"two player":           func(c *GameConfig) { c.TwoPlayer = true },
+		"block window":         func(c *GameConfig) { c.BlockWindow = 500 },
+	} {
+		option := config
+		option.BeesMissChance = 0
+		set(&option)
+		game = NewGameWithConfig(option)
+		game.Out = io.Discard
+		game.Player.HP = 5
+		if game.DefeatInevitable() {
+			t.Errorf("Expected %s never to make defeat certain", name)
+		}
+	}
+
+	// Healers never sting, so a hive of only healers can't win
+	config.BeesMissChance = 0
+	config.HealerDrones = config.DroneCount
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.HP = 5
+	if game.DefeatInevitable() {
+		t.Error("Expected a hive of healers never to make defeat certain")
+	}
+
+	// One hit on a wounded Queen still wins, so the game goes on
+	config = DefaultConfig()
+	config.MercyRule = true
+	config.BeesMissChance = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game = NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.Player.HP = QueenDamage + 1 // Low enough to look hopeless, but no single sting kills
+	game.GetBeesByType(Queen)[0].HP = QueenTakesDamage
+	game.BeeTurn()
+	if game.IsGameOver() {
+		t.Error("Expected the mercy rule to spare a player one hit from victory")
+	}
+}
+
+// Test the focus-queen auto strategy aims every early attack at the Queen
+func TestAutoStrategyFocusQueen(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoStrategy = AutoStrategyFocusQueen
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 1
+	config.AutoModeDelay = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.In = strings.NewReader("")
+	game.AutoMode = true
+	queen := game.GetBeesByType(Queen)[0]
+
+	result := game.PlayGame()
+
+	if want := QueenHP / QueenTakesDamage; !result.PlayerWon || result.Turns != want {
+		t.Fatalf("Expected the Queen to fall to %d straight hits, got %+v", want, result)
+	}
+	for _, record := range game.Transcript() {
+		if record.Actor == "player" && record.BeeID != 0 && record.BeeID != queen.ID {
+			t.Errorf("Turn %d: expected the Queen to be targeted, hit bee #%d", record.Turn, record.BeeID)
+		}
+	}
+
+	config.AutoStrategy = "turtle"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an unknown auto strategy to be rejected")
+	}
+}
@@ -49,6 +49,13 @@ func TestNewGame(t *testing.T) {
 	if len(drones) != 25 {
 		t.Errorf("Expected 25 Drone bees, got %d", len(drones))
 	}
+
+	// Bees are numbered in creation order: Queens, then Workers, then Drones
+	for i, bee := range aliveBees {
+		if bee.ID != i+1 {
+			t.Errorf("Expected bee %d to have ID %d, got %d", i, i+1, bee.ID)
+		}
+	}
 }
 
 func TestBeeInitialStats(t *testing.T) {
@@ -302,6 +309,30 @@ func TestPlayerTakeDamage(t *testing.T) {
 	}
 }
 
+func TestPlayerHeal(t *testing.T) {
+	player := NewPlayer()
+	player.TakeDamage(30)
+
+	// Test normal healing
+	player.Heal(10)
+	if player.HP != 80 {
+		t.Errorf("Expected player to have 80 HP after healing 10, got %d", player.HP)
+	}
+
+	// Test healing is clamped at max HP
+	player.Heal(50)
+	if player.HP != player.MaxHP {
+		t.Errorf("Expected healing to stop at %d HP, got %d", player.MaxHP, player.HP)
+	}
+
+	// Test non-positive healing does nothing
+	player.TakeDamage(10)
+	player.Heal(-5)
+	if player.HP != 90 {
+		t.Errorf("Expected negative healing to be ignored, got %d HP", player.HP)
+	}
+}
+
 func TestIsGameOverConditions(t *testing.T) {
 	game := NewGame()
 
@@ -352,3 +383,151 @@ func TestQueenDeathRule(t *testing.T) {
 		t.Errorf("Expected 0 alive bees after queen death, got %d", len(aliveBees))
 	}
 }
+
+// Test a random hive rolls a reproducible composition within the configured bounds
+func TestRandomHiveComposition(t *testing.T) {
+	config := DefaultConfig()
+	config.RandomHive = true
+	config.BeeSeed = 42
+	config.HiveRanges = map[BeeType]CountRange{
+		Worker: {Min: 2, Max: 9},
+		Drone:  {Min: 10, Max: 40},
+	}
+
+	composition := func(game *Game) [3]int {
+		return [3]int{len(game.Hive[Queen]), len(game.Hive[Worker]), len(game.Hive[Drone])}
+	}
+
+	first := composition(NewGameWithConfig(config))
+	second := composition(NewGameWithConfig(config))
+	if first != second {
+		t.Errorf("Expected the same seed to roll the same hive, got %v and %v", first, second)
+	}
+
+	if first[0] != DefaultQueenCount {
+		t.Errorf("Expected Queens without a range to use the fixed count %d, got %d", DefaultQueenCount, first[0])
+	}
+	if first[1] < 2 || first[1] > 9 {
+		t.Errorf("Expected 2-9 Workers, got %d", first[1])
+	}
+	if first[2] < 10 || first[2] > 40 {
+		t.Errorf("Expected 10-40 Drones, got %d", first[2])
+	}
+
+	// Over many seeds the rolls actually vary
+	seen := make(map[[3]int]bool)
+	for seed := int64(1); seed <= 20; seed++ {
+		config.BeeSeed = seed
+		seen[composition(NewGameWithConfig(config))] = true
+	}
+	if len(seen) < 2 {
+		t.Error("Expected different seeds to roll different hives")
+	}
+}
+
+// Test Clone copies a bee without sharing state
+func TestBeeClone(t *testing.T) {
+	bee := NewBee(Worker)
+	bee.ID = 4
+	bee.HP = 30
+	clone := bee.Clone()
+
+	if *clone != *bee {
+		t.Errorf("Expected clone %+v to match %+v", *clone, *bee)
+	}
+	clone.HP = 1
+	if bee.HP != 30 {
+		t.Errorf("Changing the clone should not affect the original, HP is %d", bee.HP)
+	}
+}
+
+// Test SnapshotHive reflects current HP and can't be used to change the live hive
+func TestSnapshotHive(t *testing.T) {
+	game := NewGame()
+	queen := game.GetBeesByType(Queen)[0]
+	queen.HP = 42
+	game.GetBeesByType(Drone)[0].HP = 0
+
+	snapshot := game.SnapshotHive()
+	if len(snapshot[Queen]) != 1 || len(snapshot[Worker]) != DefaultWorkerCount || len(snapshot[Drone]) != DefaultDroneCount {
+		t.Fatalf("Expected every bee in the snapshot, got %d/%d/%d", len(snapshot[Queen]), len(snapshot[Worker]), len(snapshot[Drone]))
+	}
+	if snapshot[Queen][0].HP != 42 {
+		t.Errorf("Expected snapshot Queen HP 42, got %d", snapshot[Queen][0].HP)
+	}
+	if snapshot[Drone][0].HP != 0 {
+		t.Errorf("Expected dead bees in the snapshot too, got HP %d", snapshot[Drone][0].HP)
+	}
+
+	snapshot[Queen][0].HP = 0
+	snapshot[Worker] = nil
+	if queen.HP != 42 || len(game.GetBeesByType(Worker)) != DefaultWorkerCount {
+		t.Error("Mutating the snapshot should not affect the live hive")
+	}
+}
+
+// Test NameHive gives every bee a distinct name that the bee seed reproduces exactly
+func TestNameHiveDeterministic(t *testing.T) {
+	config := DefaultConfig()
+	config.NameHive = true
+	config.BeeSeed = 9
+
+	names := func(game *Game) []string {
+		var result []string
+		for _, beeType := range []BeeType{Queen, Worker, Drone} {
+			for _, bee := range game.Hive[beeType] {
+				result = append(result, bee.Name)
+			}
+		}
+		return result
+	}
+
+	first := names(NewGameWithConfig(config))
+	second := names(NewGameWithConfig(config))
+	if len(first) != len(second) {
+		t.Fatalf("Expected the same number of bees, got %d and %d", len(first), len(second))
+	}
+
+	seen := make(map[string]bool)
+	for i, name := range first {
+		if name == "" {
+			t.Errorf("Expected bee %d to be named", i)
+		}
+		if name != second[i] {
+			t.Errorf("Expected the same seed to name bee %d the same, got %q and %q", i, name, second[i])
+		}
+		if seen[name] {
+			t.Errorf("Expected every name to be distinct, %q was repeated", name)
+		}
+		seen[name] = true
+	}
+
+	// Names are off by default
+	if bee := NewGame().GetBeesByType(Queen)[0]; bee.Name != "" {
+		t.Errorf("Expected unnamed bees by default, got %q", bee.Name)
+	}
+}
+
+// Test the hive health bar is full for a fresh hive and about half full once half the HP is gone
+func TestHiveHealthBar(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 2
+	config.DroneCount = 0
+	game := NewGameWithConfig(config)
+
+	if bar := game.HiveHealthBar(10); bar != "[##########] 100%" {
+		t.Errorf("Expected a full bar for a fresh hive, got %q", bar)
+	}
+
+	// Killing one of two identical Workers leaves half the hive's HP
+	game.GetBeesByType(Worker)[0].HP = 0
+	if bar := game.HiveHealthBar(10); bar != "[#####-----] 50%" {
+		t.Errorf("Expected a half bar, got %q", bar)
+	}
+
+	game.GetBeesByType(Worker)[0].HP = 0
+	if bar := game.HiveHealthBar(4); bar != "[----] 0%" {
+		t.Errorf("Expected an empty bar for a destroyed hive, got %q", bar)
+	}
+}
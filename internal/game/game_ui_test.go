@@ -2,6 +2,7 @@ package game
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -313,3 +314,619 @@ func TestPlayGameCompleteFlow(t *testing.T) {
 		t.Error("Expected game to be over after complete flow")
 	}
 }
+
+// Test PlayGame restart command resets the game state
+func TestPlayGameRestartCommand(t *testing.T) {
+	game := NewGame()
+	monitorChannel := game.damageEvent
+
+	// Mock stdin with a turn, a restart and then quit
+	input := "hit\nrestart\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	if !strings.Contains(output, "Restarting the game...") {
+		t.Errorf("Expected restart message, got: %s", output)
+	}
+
+	// Turn counter and player should be back to their starting values
+	if game.Turns != 0 {
+		t.Errorf("Expected turns to reset to 0 after restart, got %d", game.Turns)
+	}
+	if game.Player.HP != game.Player.MaxHP {
+		t.Errorf("Expected player to be back at full health, got %d/%d", game.Player.HP, game.Player.MaxHP)
+	}
+
+	// The hive should be full again
+	if len(game.GetAliveBees()) != DefaultTotalBees {
+		t.Errorf("Expected %d bees after restart, got %d", DefaultTotalBees, len(game.GetAliveBees()))
+	}
+	for _, bee := range game.GetAliveBees() {
+		if bee.HP != bee.MaxHP {
+			t.Errorf("Expected %s bee at full health after restart, got %d/%d", bee.Type, bee.HP, bee.MaxHP)
+		}
+	}
+
+	// Restarting must reuse the existing monitor channel rather than spawning a new one
+	if game.damageEvent != monitorChannel {
+		t.Error("Expected restart to reuse the existing damage monitor channel")
+	}
+}
+
+// Test PlayGame repeats the previous command with '!!'
+func TestPlayGameRepeatLastCommand(t *testing.T) {
+	game := NewGame()
+
+	// Mock stdin with a hit, a repeat and then quit
+	input := "hit\n!!\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	if !strings.Contains(output, "Repeating 'hit'") {
+		t.Errorf("Expected repeat message, got: %s", output)
+	}
+	if game.Turns != 2 {
+		t.Errorf("Expected '!!' to replay the hit for 2 turns, got %d", game.Turns)
+	}
+	if game.LastCommand != "hit" {
+		t.Errorf("Expected last command to be 'hit', got '%s'", game.LastCommand)
+	}
+}
+
+// Test PlayGame '!!' with no history doesn't take a turn
+func TestPlayGameRepeatWithoutHistory(t *testing.T) {
+	game := NewGame()
+
+	input := "!!\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	if !strings.Contains(output, "No previous command to repeat.") {
+		t.Errorf("Expected no-history message, got: %s", output)
+	}
+	if game.Turns != 0 {
+		t.Errorf("Expected no turns without history, got %d", game.Turns)
+	}
+}
+
+// Test the debug 'reveal' command prints exact HP without using a turn
+func TestPlayGameRevealCommand(t *testing.T) {
+	config := DefaultConfig()
+	config.DebugMode = true
+	game := NewGameWithConfig(config)
+
+	// Damage a few bees so their HP is distinctive
+	game.GetBeesByType(Queen)[0].TakeDamage()
+	worker := game.GetBeesByType(Worker)[1]
+	worker.TakeDamage()
+	drone := game.GetBeesByType(Drone)[0]
+	drone.TakeDamage()
+
+	input := "reveal\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	expectedLines := []string{
+		"#1 Queen: 90/100 HP",
+		fmt.Sprintf("#%d Worker: 50/75 HP", worker.ID),
+		fmt.Sprintf("#%d Drone: 30/60 HP", drone.ID),
+		"#2 Worker: 75/75 HP",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected reveal output to contain '%s', got: %s", line, output)
+		}
+	}
+	if game.Turns != 0 {
+		t.Errorf("Expected reveal not to use a turn, got %d turns", game.Turns)
+	}
+}
+
+// Test 'reveal' is refused outside debug mode
+func TestPlayGameRevealRequiresDebugMode(t *testing.T) {
+	game := NewGame()
+
+	input := "reveal\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	if !strings.Contains(output, "only available in debug mode") {
+		t.Errorf("Expected debug mode message, got: %s", output)
+	}
+	if strings.Contains(output, "Hive Reveal") {
+		t.Error("Expected hive not to be revealed outside debug mode")
+	}
+}
+
+// Test PlayGame accepts single-letter abbreviations and a custom prompt
+func TestPlayGameAbbreviations(t *testing.T) {
+	config := DefaultConfig()
+	config.Prompt = "buzz> "
+	game := NewGameWithConfig(config)
+
+	input := "h\nq\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	if game.Turns != 1 {
+		t.Errorf("Expected 'h' to take a turn, got %d turns", game.Turns)
+	}
+	if !strings.Contains(output, "Thanks for playing!") {
+		t.Errorf("Expected 'q' to quit the game, got: %s", output)
+	}
+	if !strings.Contains(output, "buzz> ") || strings.Contains(output, DefaultPrompt) {
+		t.Errorf("Expected the custom prompt instead of the default, got: %s", output)
+	}
+}
+
+// Test the in-game help lists the commands without using a turn
+func TestPlayGameHelpCommand(t *testing.T) {
+	game := NewGame()
+
+	input := "help\n?\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	if strings.Count(output, "=== Commands ===") != 2 {
+		t.Errorf("Expected both 'help' and '?' to print the command list, got: %s", output)
+	}
+	for _, command := range interactiveCommands {
+		if !strings.Contains(output, command.Name) {
+			t.Errorf("Expected help to list %q, got: %s", command.Name, output)
+		}
+	}
+	if game.Turns != 0 {
+		t.Errorf("Expected help not to use a turn, got %d turns", game.Turns)
+	}
+}
+
+// Test the odds command prints the configured chances without using a turn
+func TestPlayGameOddsCommand(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0.25
+	config.BeesMissChance = 0.5
+	config.QueenCount = 0
+	config.WorkerCount = 1
+	config.DroneCount = 1
+	game := NewGameWithConfig(config)
+
+	input := "odds\nquit\n"
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+		w.Write([]byte(input))
+	}()
+
+	output := captureStdout(func() { game.PlayGame() })
+	os.Stdin = oldStdin
+
+	expectedPhrases := []string{
+		"Your miss chance: 25.0% (hit chance 75.0%)",
+		"Each bee's miss chance: 50.0%",
+		"Chance at least one of the 2 bees stings you this turn: 75.0%",
+	}
+	for _, phrase := range expectedPhrases {
+		if !strings.Contains(output, phrase) {
+			t.Errorf("Expected odds output to contain %q, got: %s", phrase, output)
+		}
+	}
+	if game.Turns != 0 {
+		t.Errorf("Expected odds not to use a turn, got %d turns", game.Turns)
+	}
+}
+
+// Test auto mode prints a compact one-line summary of each turn
+func TestAutoModeTurnSummary(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 1
+	config.DroneCount = 0
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 1
+	config.DisableThinkDelay = true
+	game := NewGameWithConfig(config)
+	var out bytes.Buffer
+	game.Out = &out
+	game.AutoMode = true
+
+	game.playRound("hit")
+
+	want := "T1: you hit Worker#1 (-25), the bees missed, HP 100/100, bees 1\n"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("Expected the summary line %q, got: %s", want, out.String())
+	}
+
+	// Outside auto mode the summary only appears when asked for
+	game.AutoMode = false
+	out.Reset()
+	game.playRound("hit")
+	if strings.Contains(out.String(), "T2:") {
+		t.Errorf("Expected no summary outside auto mode, got: %s", out.String())
+	}
+	game.Config.TurnSummary = true
+	out.Reset()
+	game.playRound("hit")
+	if !strings.Contains(out.String(), "T3: you killed Worker#1 (-25), HP 100/100, bees 0") {
+		t.Errorf("Expected the summary with TurnSummary set, got: %s", out.String())
+	}
+}
+
+// Test inspect reports a damaged bee's HP without using a turn and rejects unknown or dead IDs
+func TestPlayGameInspectCommand(t *testing.T) {
+	game := NewGame()
+	var out bytes.Buffer
+	game.Out = &out
+
+	worker := game.GetBeesByType(Worker)[0]
+	worker.HP = 30
+	dead := game.GetBeesByType(Drone)[0]
+	dead.HP = 0
+
+	game.In = strings.NewReader(fmt.Sprintf("inspect %d\ninspect %d\ninspect 999\ninspect x\nquit\n", worker.ID, dead.ID))
+	game.PlayGame()
+
+	output := out.String()
+	expectedPhrases := []string{
+		fmt.Sprintf("=== Bee #%d ===", worker.ID),
+		"Type: Worker",
+		fmt.Sprintf("HP: 30/%d", WorkerHP),
+		fmt.Sprintf("Damage: %d", WorkerDamage),
+		fmt.Sprintf("The Drone bee (#%d) is already dead.", dead.ID),
+		"There is no bee #999 in the hive.",
+		`Can't inspect: "x" is not a bee ID.`,
+	}
+	for _, phrase := range expectedPhrases {
+		if !strings.Contains(output, phrase) {
+			t.Errorf("Expected inspect output to contain %q, got: %s", phrase, output)
+		}
+	}
+	if strings.Contains(output, "Level:") {
+		t.Error("Expected no level without bee leveling")
+	}
+	if game.Turns != 0 {
+		t.Errorf("Expected inspect not to use a turn, got %d turns", game.Turns)
+	}
+}
+
+// Test a seeded auto game with no delays plays to its natural end, the same way every time
+func TestPlayGameAutoModeDeterministic(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoModeDelay = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	config.PlayerSeed = 17
+	config.BeeSeed = 23
+
+	play := func() GameResult {
+		game := NewGameWithConfig(config)
+		game.Out = io.Discard
+		game.In = strings.NewReader("auto\n")
+		return game.PlayGame()
+	}
+
+	start := time.Now()
+	first := play()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected an undelayed auto game to finish quickly, took %v", elapsed)
+	}
+	if !first.Over {
+		t.Fatalf("Expected auto mode to play to the end, got %+v", first)
+	}
+	if second := play(); second != first {
+		t.Errorf("Expected the same seeds to give the same game, got %+v and %+v", first, second)
+	}
+}
+
+// Test the hive map shows healthy, wounded and dead bees with the right glyphs
+func TestRenderHiveMap(t *testing.T) {
+	game := NewGame()
+	workers := game.GetBeesByType(Worker)
+	workers[0].HP = 0
+	workers[1].HP = 10
+	drones := game.GetBeesByType(Drone)
+	drones[0].HP = 0
+	drones[1].HP = 0
+	drones[2].HP = 30
+
+	hiveMap := game.RenderHiveMap()
+	counts := map[rune]int{}
+	for _, glyph := range hiveMap {
+		counts[glyph]++
+	}
+	expected := map[rune]int{'Q': 1, 'q': 0, 'W': 3, 'w': 1, 'D': 22, 'd': 1}
+	for glyph, want := range expected {
+		if counts[glyph] != want {
+			t.Errorf("Expected %d %q glyphs, got %d in:\n%s", want, glyph, counts[glyph], hiveMap)
+		}
+	}
+
+	// 1 Queen row, 1 Worker row and 3 rows of up to HiveMapWidth Drones
+	lines := strings.Split(strings.TrimSuffix(hiveMap, "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 map rows, got %d:\n%s", len(lines), hiveMap)
+	}
+	if lines[1] != "|  w W W W|" {
+		t.Errorf("Expected the dead Worker blank and the wounded one lowercase, got %q", lines[1])
+	}
+}
+
+// Test the exported grid lays the hive out in rows with a glyph per bee
+func TestExportGrid(t *testing.T) {
+	game := NewGame()
+	game.GetBeesByType(Queen)[0].HP = QueenHP - 1
+	workers := game.GetBeesByType(Worker)
+	workers[2].HP = 0
+	drones := game.GetBeesByType(Drone)
+	drones[HiveMapWidth].HP = 1
+
+	grid := game.ExportGrid()
+
+	// 1 Queen row, 1 Worker row and 3 rows for 25 Drones
+	wantLens := []int{1, 5, HiveMapWidth, HiveMapWidth, 5}
+	if len(grid) != len(wantLens) {
+		t.Fatalf("Expected %d grid rows, got %d", len(wantLens), len(grid))
+	}
+	for i, want := range wantLens {
+		if len(grid[i]) != want {
+			t.Errorf("Expected row %d to hold %d bees, got %d", i, want, len(grid[i]))
+		}
+	}
+
+	if grid[0][0] != 'q' {
+		t.Errorf("Expected the wounded Queen as 'q', got %q", grid[0][0])
+	}
+	if string(grid[1]) != "WW WW" {
+		t.Errorf("Expected the third Worker blank, got %q", string(grid[1]))
+	}
+	if grid[3][0] != 'd' || grid[3][1] != 'D' {
+		t.Errorf("Expected the first Drone of the second Drone row wounded, got %q", string(grid[3]))
+	}
+}
+
+// slowReader holds its input back until the delay has passed, like a player who stepped away
+type slowReader struct {
+	delay  time.Duration
+	r      io.Reader
+	waited bool
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	if !s.waited {
+		time.Sleep(s.delay)
+		s.waited = true
+	}
+	return s.r.Read(p)
+}
+
+// Test the background reader started for InputTimeout exits once PlayGame returns, rather than
+// blocking forever on input read ahead that nobody will ask for
+func TestInputReaderStopsWithPlayGame(t *testing.T) {
+	config := DefaultConfig()
+	config.InputTimeout = 1000
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.In = strings.NewReader("quit\nhit\nhit\n")
+	game.Out = io.Discard
+
+	game.PlayGame()
+
+	select {
+	case <-game.linesDone:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the background reader to exit when PlayGame returned")
+	}
+}
+
+// Test a game started in auto mode plays to the end without reading any input
+func TestPlayGameStartsInAutoMode(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoModeDelay = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	config.PlayerSeed = 42
+	config.BeeSeed = 42
+	game := NewGameWithConfig(config)
+	game.AutoMode = true
+	game.In = strings.NewReader("")
+	game.Out = io.Discard
+
+	result := game.PlayGame()
+
+	if !result.Over {
+		t.Fatalf("Expected auto mode to play the game to completion, got %+v", result)
+	}
+	if result.Turns == 0 {
+		t.Error("Expected turns to have been played")
+	}
+}
+
+// Test typing 'block' inside the window negates a telegraphed sting, and a late reply lets it land
+func TestBlockWindow(t *testing.T) {
+	config := DefaultConfig()
+	config.BlockWindow = 200
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+
+	game := newSingleBeeGame(config, Worker)
+	game.In = strings.NewReader("block\n")
+	var out bytes.Buffer
+	game.Out = &out
+	game.interactive = true // As while PlayGame reads commands
+	game.BeeTurn()
+
+	if game.Player.HP != 100 {
+		t.Errorf("Expected the blocked sting to deal no damage, player has %d HP", game.Player.HP)
+	}
+	if !strings.Contains(out.String(), "rears back to sting") || !strings.Contains(out.String(), "You block the sting") {
+		t.Errorf("Expected the sting telegraphed and blocked, got: %s", out.String())
+	}
+	transcript := game.Transcript()
+	if len(transcript) != 1 || transcript[0].Action != EventStingBlocked {
+		t.Errorf("Expected a single sting_blocked record, got %+v", transcript)
+	}
+
+	// Too slow: the window closes on the game's clock before the reply arrives
+	game = newSingleBeeGame(config, Worker)
+	game.In = &slowReader{delay: 200 * time.Millisecond, r: strings.NewReader("block\n")}
+	game.Clock = newFakeClock()
+	out.Reset()
+	game.Out = &out
+	game.interactive = true
+	game.BeeTurn()
+
+	if game.Player.HP != 100-WorkerDamage {
+		t.Errorf("Expected the unblocked sting to deal %d damage, player has %d HP", WorkerDamage, game.Player.HP)
+	}
+	if !strings.Contains(out.String(), "Too slow!") {
+		t.Errorf("Expected the missed window to be reported, got: %s", out.String())
+	}
+
+	// Step has nobody to ask, so the sting lands without a telegraph
+	game = newSingleBeeGame(config, Worker)
+	game.In = strings.NewReader("block\n")
+	out.Reset()
+	game.Out = &out
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if strings.Contains(out.String(), "rears back to sting") {
+		t.Errorf("Expected Step not to telegraph stings, got: %s", out.String())
+	}
+}
+
+// Test an idle player has turns auto-played once InputTimeout passes, then input resumes normally
+func TestInputTimeoutAutoPlaysTurn(t *testing.T) {
+	config := DefaultConfig()
+	config.InputTimeout = 20
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.In = &slowReader{delay: 200 * time.Millisecond, r: strings.NewReader("quit\n")}
+	var out bytes.Buffer
+	game.Out = &out
+
+	result := game.PlayGame()
+
+	if game.Turns < 1 {
+		t.Errorf("Expected the timeout to auto-play at least one turn, %d turns played", game.Turns)
+	}
+	if !strings.Contains(out.String(), "playing a turn for you") {
+		t.Errorf("Expected a timeout notice, got: %s", out.String())
+	}
+	if result.Over {
+		t.Errorf("Expected the late 'quit' to end the game unfinished, got %+v", result)
+	}
+
+	// Input running out still ends the game rather than timing out forever
+	game = NewGameWithConfig(config)
+	game.In = strings.NewReader("")
+	game.Out = io.Discard
+	if result := game.PlayGame(); result.Over || game.Turns != 0 {
+		t.Errorf("Expected EOF to stop the game straight away, %d turns played", game.Turns)
+	}
+}
+
+// Test 'aim' lists the bees and attacks the one picked, re-asking after bad picks without using the turn,
+// while a longer word that merely starts with "aim" isn't taken for it
+func TestAimCommand(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 1
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	game.In = strings.NewReader("aimless\naim\n99\nthree\n3\nquit\n")
+	var out bytes.Buffer
+	game.Out = &out
+
+	game.PlayGame()
+
+	var target *Bee
+	for _, bee := range game.GetAliveBees() {
+		if bee.ID == 3 {
+			target = bee
+		}
+	}
+	if target == nil || target.HP != target.MaxHP-game.getDamageDealtTo(target.Type) {
+		t.Fatalf("Expected bee #3 to take one hit, got %+v", target)
+	}
+	damaged := 0
+	for _, bee := range game.GetAliveBees() {
+		if bee.HP < bee.MaxHP {
+			damaged++
+		}
+	}
+	if damaged != 1 || game.Turns != 1 {
+		t.Errorf("Expected exactly one bee hit in one turn, %d damaged over %d turns", damaged, game.Turns)
+	}
+	if !strings.Contains(out.String(), "#3 ") || strings.Count(out.String(), "No living bee has ID") != 2 {
+		t.Errorf("Expected the bee list and two rejected picks, got: %s", out.String())
+	}
+}
@@ -0,0 +1,37 @@
+package game
+
+import "fmt"
+
+// Hive codes pack the Queen, Worker and Drone counts into one integer, 16 bits each
+const (
+	hiveCodeBits     = 16
+	HiveCodeMaxCount = 1<<hiveCodeBits - 1 // Largest count of any one bee type a hive code can hold
+)
+
+// EncodeHive packs the config's bee counts into a single integer, clamping each count to 0-HiveCodeMaxCount
+func EncodeHive(c GameConfig) uint64 {
+	pack := func(count int) uint64 {
+		return uint64(max(0, min(count, HiveCodeMaxCount)))
+	}
+	return pack(c.QueenCount) | pack(c.WorkerCount)<<hiveCodeBits | pack(c.DroneCount)<<(2*hiveCodeBits)
+}
+
+// DecodeHive unpacks a hive code into DefaultConfig with its bee counts, rejecting codes
+// with unused bits set or hives larger than the default maximum hive size
+func DecodeHive(v uint64) (GameConfig, error) {
+	if v>>(3*hiveCodeBits) != 0 {
+		return GameConfig{}, fmt.Errorf("hive code %d is out of range", v)
+	}
+
+	unpack := func(shift int) int {
+		return int(v >> shift & HiveCodeMaxCount)
+	}
+	config := DefaultConfig()
+	config.QueenCount = unpack(0)
+	config.WorkerCount = unpack(hiveCodeBits)
+	config.DroneCount = unpack(2 * hiveCodeBits)
+	if err := config.Validate(); err != nil {
+		return GameConfig{}, fmt.Errorf("invalid hive code %d: %w", v, err)
+	}
+	return config, nil
+}
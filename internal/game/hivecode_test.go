@@ -0,0 +1,47 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test a hive code decodes back to the counts it was packed from
+func TestHiveCodeRoundTrip(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 2
+	config.WorkerCount = 12
+	config.DroneCount = 300
+
+	decoded, err := DecodeHive(EncodeHive(config))
+	if err != nil {
+		t.Fatalf("Expected the code to decode, got %v", err)
+	}
+	if decoded.QueenCount != 2 || decoded.WorkerCount != 12 || decoded.DroneCount != 300 {
+		t.Errorf("Expected 2/12/300 bees, got %d/%d/%d", decoded.QueenCount, decoded.WorkerCount, decoded.DroneCount)
+	}
+
+	// The default hive has a well-known code
+	if code := EncodeHive(DefaultConfig()); code != 1|5<<16|25<<32 {
+		t.Errorf("Unexpected code %d for the default hive", code)
+	}
+}
+
+// Test codes with out-of-range counts or stray bits are rejected
+func TestDecodeHiveOutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		code uint64
+		want string
+	}{
+		{"too many drones", 1 | 5<<16 | 20000<<32, "exceeds the maximum hive size"},
+		{"unused bits", 1 << 50, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeHive(tt.code)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
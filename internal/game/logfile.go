@@ -0,0 +1,48 @@
+package game
+
+import (
+	"os"
+	"sync"
+)
+
+// DefaultLogFileMaxSize is how large the LogFile grows, in bytes, before it is rotated when LogFileMaxSize is unset
+const DefaultLogFileMaxSize = 1 << 20
+
+// RotatingWriter appends to a file, first renaming it to path+".1" (replacing any older
+// rotation) once the next write would take it past maxSize bytes. The file is opened for
+// each write, so several games logging to the same path, as a simulation does, share one log.
+type RotatingWriter struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+}
+
+// NewRotatingWriter returns a writer for path that rotates past maxSize bytes (0 or less uses DefaultLogFileMaxSize)
+func NewRotatingWriter(path string, maxSize int64) *RotatingWriter {
+	if maxSize <= 0 {
+		maxSize = DefaultLogFileMaxSize
+	}
+	return &RotatingWriter{path: path, maxSize: maxSize}
+}
+
+// Write appends p in a single write, rotating first if needed so a line is never split across files
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if info, err := os.Stat(w.path); err == nil && info.Size() > 0 && info.Size()+int64(len(p)) > w.maxSize {
+		if err := os.Rename(w.path, w.path+".1"); err != nil {
+			return 0, err
+		}
+	}
+
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	n, err := file.Write(p)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
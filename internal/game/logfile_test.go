@@ -0,0 +1,55 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test LogFile rotates once full, leaving both files as whole JSON lines
+func TestLogFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	config := DefaultConfig()
+	config.LogFile = path
+	config.LogFileMaxSize = 512 // A few events' worth
+	config.DisableMonitor = true
+	config.DisableThinkDelay = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	for i := 0; i < 10 && !game.IsGameOver(); i++ {
+		if err := game.Step("hit"); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1"} {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("Expected log file %s: %v", filepath.Base(name), err)
+		}
+		defer file.Close()
+
+		info, _ := file.Stat()
+		if info.Size() > config.LogFileMaxSize {
+			t.Errorf("Expected %s to stay within %d bytes, got %d", filepath.Base(name), config.LogFileMaxSize, info.Size())
+		}
+
+		lines := 0
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var event GameEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				t.Fatalf("Expected valid JSON in %s, got %q: %v", filepath.Base(name), scanner.Text(), err)
+			}
+			lines++
+		}
+		if lines == 0 {
+			t.Errorf("Expected events in %s", filepath.Base(name))
+		}
+	}
+}
@@ -30,3 +30,14 @@ func (p *Player) TakeDamage(damage int) {
 func (p Player) IsAlive() bool {
 	return p.HP > 0
 }
+
+// Heal restores health to the player without going over their maximum
+func (p *Player) Heal(amount int) {
+	if amount <= 0 {
+		return
+	}
+	p.HP += amount
+	if p.HP > p.MaxHP {
+		p.HP = p.MaxHP
+	}
+}
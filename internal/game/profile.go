@@ -0,0 +1,33 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// DecisionProfile measures one bee turn's concurrent decisions: how long they took on the
+// wall clock against how long the same think times would take one bee after another
+type DecisionProfile struct {
+	Bees   int           // Bees that made a decision
+	Wall   time.Duration // Time from the first decision starting to the last one finishing
+	Serial time.Duration // Sum of every bee's own decision time
+}
+
+// Speedup is how many times faster deciding concurrently was than deciding serially (0 when no time passed)
+func (p DecisionProfile) Speedup() float64 {
+	if p.Wall <= 0 {
+		return 0
+	}
+	return float64(p.Serial) / float64(p.Wall)
+}
+
+// String formats the profile the way ProfileTurns reports it
+func (p DecisionProfile) String() string {
+	return fmt.Sprintf("decisions: %d bees, wall %.1f ms, serial-equivalent %.1f ms, speedup %.2fx",
+		p.Bees, milliseconds(p.Wall), milliseconds(p.Serial), p.Speedup())
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,32 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test the decision profile computes its speedup and formats the report
+func TestDecisionProfile(t *testing.T) {
+	profile := DecisionProfile{Bees: 3, Wall: 50 * time.Millisecond, Serial: 150 * time.Millisecond}
+	if profile.Speedup() != 3 {
+		t.Errorf("Expected a 3x speedup, got %v", profile.Speedup())
+	}
+	if want := "decisions: 3 bees, wall 50.0 ms, serial-equivalent 150.0 ms, speedup 3.00x"; profile.String() != want {
+		t.Errorf("Expected %q, got %q", want, profile.String())
+	}
+
+	// With think delays off and the clock frozen no time passes at all
+	config := DefaultConfig()
+	config.ProfileTurns = true
+	game := NewDeterministicGame(config, 5)
+	var out bytes.Buffer
+	game.Out = &out
+	game.BeeTurn()
+
+	want := "decisions: 31 bees, wall 0.0 ms, serial-equivalent 0.0 ms, speedup 0.00x"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("Expected the report %q, got: %s", want, out.String())
+	}
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SavedGame is the serializable state of a game in progress.
+// Random number generator state isn't saved, so a loaded seeded game won't replay the same rolls.
+type SavedGame struct {
+	Config         GameConfig             `json:"config"`
+	Turns          int                    `json:"turns"`
+	PlayerHP       int                    `json:"player_hp"`
+	PlayerMaxHP    int                    `json:"player_max_hp"`
+	Bees           []Bee                  `json:"bees"`
+	LastCommand    string                 `json:"last_command,omitempty"`
+	HasShield      bool                   `json:"has_shield"`
+	Rage           int                    `json:"rage"`
+	Reinforcements int                    `json:"reinforcements"`
+	CleavesLeft    int                    `json:"cleaves_left"`
+	Stamina        int                    `json:"stamina"`
+	Morale         int                    `json:"morale"`
+	Enraged        bool                   `json:"enraged"`
+	Revived        bool                   `json:"revived"`
+	LivesLeft      int                    `json:"lives_left"`
+	Fled           []FledBee              `json:"fled,omitempty"`
+	Round          int                    `json:"round"`
+	DamageDealt    int                    `json:"damage_dealt"`
+	DamageTaken    int                    `json:"damage_taken"`
+	TotalHealed    int                    `json:"total_healed"`
+	DamageByBee    map[int]int            `json:"damage_by_bee,omitempty"`
+	DamageByType   map[BeeType]TypeDamage `json:"damage_by_type,omitempty"`
+}
+
+// SaveState writes the game's current state as JSON
+func (g *Game) SaveState(w io.Writer) error {
+	g.mu.RLock()
+	saved := SavedGame{
+		Config:         g.Config,
+		Turns:          g.Turns,
+		PlayerHP:       g.Player.HP,
+		PlayerMaxHP:    g.Player.MaxHP,
+		LastCommand:    g.LastCommand,
+		HasShield:      g.HasShield,
+		Rage:           g.Rage,
+		Reinforcements: g.Reinforcements,
+		CleavesLeft:    g.CleavesLeft,
+		Stamina:        g.Stamina,
+		Morale:         g.Morale,
+		Enraged:        g.Enraged,
+		Revived:        g.Revived,
+		LivesLeft:      g.LivesLeft,
+		Round:          g.Round,
+		DamageDealt:    g.DamageDealt,
+		DamageTaken:    g.DamageTaken,
+		TotalHealed:    g.TotalHealed,
+		DamageByBee:    make(map[int]int, len(g.damageByBee)),
+		DamageByType:   make(map[BeeType]TypeDamage, len(g.damageByType)),
+	}
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		for _, bee := range g.Hive[beeType] {
+			saved.Bees = append(saved.Bees, *bee)
+		}
+	}
+	for _, fled := range g.Fled {
+		saved.Fled = append(saved.Fled, FledBee{Bee: fled.Bee.Clone(), ReturnTurn: fled.ReturnTurn})
+	}
+	for id, damage := range g.damageByBee {
+		saved.DamageByBee[id] = damage
+	}
+	for beeType, damage := range g.damageByType {
+		saved.DamageByType[beeType] = damage
+	}
+	g.mu.RUnlock()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(saved)
+}
+
+// LoadState rebuilds a game from JSON written by SaveState
+func LoadState(r io.Reader) (*Game, error) {
+	var saved SavedGame
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("decoding saved game: %w", err)
+	}
+	g, err := NewGameChecked(saved.Config)
+	if err != nil {
+		return nil, fmt.Errorf("saved game config: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Turns = saved.Turns
+	g.Player = &Player{HP: saved.PlayerHP, MaxHP: saved.PlayerMaxHP}
+	g.LastCommand = saved.LastCommand
+	g.HasShield = saved.HasShield
+	g.Rage = saved.Rage
+	g.Reinforcements = saved.Reinforcements
+	g.CleavesLeft = saved.CleavesLeft
+	g.Stamina = saved.Stamina
+	g.Morale = saved.Morale
+	g.Enraged = saved.Enraged
+	g.Revived = saved.Revived
+	g.LivesLeft = max(saved.LivesLeft, 1)
+	g.Fled = saved.Fled
+	g.Round = max(saved.Round, 1)
+	g.DamageDealt = saved.DamageDealt
+	g.DamageTaken = saved.DamageTaken
+	g.TotalHealed = saved.TotalHealed
+
+	// Replace the freshly rolled hive with the saved bees
+	g.Hive = map[BeeType][]*Bee{Queen: {}, Worker: {}, Drone: {}}
+	g.nextBeeID = 0
+	g.nameCounts = make(map[string]int)
+	for i := range saved.Bees {
+		bee := saved.Bees[i]
+		g.Hive[bee.Type] = append(g.Hive[bee.Type], &bee)
+		if bee.Name != "" {
+			g.nameCounts[strings.TrimRight(bee.Name, " 0123456789")]++
+		}
+		if bee.ID > g.nextBeeID {
+			g.nextBeeID = bee.ID
+		}
+	}
+	for _, fled := range g.Fled {
+		if fled.Bee.ID > g.nextBeeID {
+			g.nextBeeID = fled.Bee.ID
+		}
+	}
+	g.rebuildAliveBeesUnsafe()
+
+	g.damageByBee = make(map[int]int, len(saved.DamageByBee))
+	for id, damage := range saved.DamageByBee {
+		g.damageByBee[id] = damage
+	}
+	g.damageByType = make(map[BeeType]TypeDamage, len(saved.DamageByType))
+	for beeType, damage := range saved.DamageByType {
+		g.damageByType[beeType] = damage
+	}
+	return g, nil
+}
+
+// autosave writes the state to AutosavePath, replacing the previous save atomically
+// so a crash mid-write never leaves a corrupt file behind
+func (g *Game) autosave() {
+	if g.Config.AutosavePath == "" {
+		return
+	}
+	if err := g.saveStateToFile(g.Config.AutosavePath); err != nil {
+		fmt.Fprintf(g.out(), "⚠️ Autosave failed: %v\n", err)
+	}
+}
+
+// saveStateToFile writes the state to a temporary file next to path and renames it into place
+func (g *Game) saveStateToFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Cleans up after a failure; a no-op once renamed
+
+	if err := g.SaveState(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test autosave writes a loadable snapshot of the game after every turn
+func TestAutosaveAfterTurn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bees.json")
+
+	config := DefaultConfig()
+	config.AutosavePath = path
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 0
+	config.DisableMonitor = true
+	config.DisableThinkDelay = true
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected an autosave file after the turn: %v", err)
+	}
+	defer file.Close()
+
+	loaded, err := LoadState(file)
+	if err != nil {
+		t.Fatalf("Expected the autosave to load: %v", err)
+	}
+
+	if loaded.Turns != 1 {
+		t.Errorf("Expected the autosave to be at turn 1, got %d", loaded.Turns)
+	}
+	if loaded.Player.HP != game.Player.HP || loaded.Player.HP == game.Player.MaxHP {
+		t.Errorf("Expected the saved player HP %d to match the stung player's %d", loaded.Player.HP, game.Player.HP)
+	}
+	if len(loaded.GetAliveBees()) != len(game.GetAliveBees()) {
+		t.Errorf("Expected %d living bees in the save, got %d", len(game.GetAliveBees()), len(loaded.GetAliveBees()))
+	}
+
+	live := game.SnapshotHive()
+	saved := loaded.SnapshotHive()
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		for i := range live[beeType] {
+			if live[beeType][i] != saved[beeType][i] {
+				t.Errorf("Expected saved %s %+v to match live %+v", beeType, saved[beeType][i], live[beeType][i])
+			}
+		}
+	}
+
+	// Only the save itself is left behind, no temp files
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected only the autosave file in the directory, found %d entries", len(entries))
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"fmt"
+	"io"
+)
+
+// Scoring constants for the standard and time attack formulas
+const (
+	ScoreVictoryBonus = 1000 // Awarded for destroying the hive and surviving
+	ScorePerKill      = 10   // Awarded for every bee killed
+	ScorePerHP        = 5    // Awarded for every HP left at the end
+	ScorePerTurn      = 5    // Deducted for every turn taken
+
+	TimeAttackPerHP   = 20  // Time attack rewards finishing healthy much more...
+	TimeAttackPerTurn = 100 // ...and punishes every extra turn heavily
+)
+
+// ScoreBreakdown shows how a final score was built up
+type ScoreBreakdown struct {
+	Formula      string // "standard" or "time attack"
+	VictoryBonus int
+	BeesKilled   int
+	PerKill      int
+	PlayerHP     int
+	PerHP        int
+	Turns        int
+	PerTurn      int
+	Total        int // Never below zero
+}
+
+// Score returns the player's score, using the time attack formula when TimeAttack is set
+func (g *Game) Score() int {
+	return g.ScoreBreakdown().Total
+}
+
+// ScoreBreakdown works out the score along with every component that went into it
+func (g *Game) ScoreBreakdown() ScoreBreakdown {
+	result := g.Result()
+	breakdown := ScoreBreakdown{
+		Formula:    "standard",
+		BeesKilled: g.totalBees() - result.BeesRemaining,
+		PerKill:    ScorePerKill,
+		PlayerHP:   max(result.PlayerHP, 0),
+		PerHP:      ScorePerHP,
+		Turns:      result.Turns,
+		PerTurn:    ScorePerTurn,
+	}
+	if g.Config.TimeAttack {
+		breakdown.Formula = "time attack"
+		breakdown.PerHP = TimeAttackPerHP
+		breakdown.PerTurn = TimeAttackPerTurn
+	}
+	if result.PlayerWon {
+		breakdown.VictoryBonus = ScoreVictoryBonus
+	}
+
+	breakdown.Total = breakdown.VictoryBonus +
+		breakdown.BeesKilled*breakdown.PerKill +
+		breakdown.PlayerHP*breakdown.PerHP -
+		breakdown.Turns*breakdown.PerTurn
+	if breakdown.Total < 0 {
+		breakdown.Total = 0
+	}
+	return breakdown
+}
+
+// Print writes the scoring formula one component per line
+func (b ScoreBreakdown) Print(w io.Writer) {
+	fmt.Fprintf(w, "\n--- SCORE (%s) ---\n", b.Formula)
+	fmt.Fprintf(w, "Victory bonus:  +%d\n", b.VictoryBonus)
+	fmt.Fprintf(w, "Bees killed:    %d x %d = +%d\n", b.BeesKilled, b.PerKill, b.BeesKilled*b.PerKill)
+	fmt.Fprintf(w, "HP remaining:   %d x %d = +%d\n", b.PlayerHP, b.PerHP, b.PlayerHP*b.PerHP)
+	fmt.Fprintf(w, "Turns taken:    %d x %d = -%d\n", b.Turns, b.PerTurn, b.Turns*b.PerTurn)
+	fmt.Fprintf(w, "Final score: %d\n", b.Total)
+}
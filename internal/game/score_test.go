@@ -0,0 +1,47 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test time attack scoring rewards a fast win far more than a slow one
+func TestTimeAttackScoring(t *testing.T) {
+	finishedGame := func(timeAttack bool, turns int) *Game {
+		config := DefaultConfig()
+		config.TimeAttack = timeAttack
+		game := NewGameWithConfig(config)
+		game.Turns = turns
+		game.Player.HP = 60
+		game.KillAllBees()
+		return game
+	}
+
+	fast := finishedGame(true, 5)
+	slow := finishedGame(true, 12)
+
+	// 1000 victory + 31 kills x 10 + 60 HP x 20 - turns x 100
+	if fast.Score() != 1000+310+1200-500 {
+		t.Errorf("Unexpected fast time attack score %d", fast.Score())
+	}
+	if slow.Score() != 1000+310+1200-1200 {
+		t.Errorf("Unexpected slow time attack score %d", slow.Score())
+	}
+	if fast.Score()-slow.Score() != 7*TimeAttackPerTurn {
+		t.Errorf("Expected each extra turn to cost %d points, got a gap of %d", TimeAttackPerTurn, fast.Score()-slow.Score())
+	}
+
+	// The standard formula barely cares about speed by comparison
+	standardGap := finishedGame(false, 5).Score() - finishedGame(false, 12).Score()
+	if standardGap != 7*ScorePerTurn {
+		t.Errorf("Expected a standard gap of %d, got %d", 7*ScorePerTurn, standardGap)
+	}
+
+	// EndGame shows how the score was built up
+	output := captureStdout(fast.EndGame)
+	for _, phrase := range []string{"SCORE (time attack)", "Turns taken:    5 x 100 = -500", "Final score: 2010"} {
+		if !strings.Contains(output, phrase) {
+			t.Errorf("Expected EndGame output to contain %q, got: %s", phrase, output)
+		}
+	}
+}
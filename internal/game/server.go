@@ -0,0 +1,126 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CommandRequest is the body accepted by POST /command
+type CommandRequest struct {
+	Command string `json:"command"`
+}
+
+// Server exposes a single game over HTTP so it can be played by scripts or other front ends
+type Server struct {
+	game *Game
+	mux  *http.ServeMux
+}
+
+// NewServer wraps a game in an HTTP handler with /status, /command and /events endpoints
+func NewServer(game *Game) *Server {
+	s := &Server{game: game, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/status", s.handleStatus)
+	s.mux.HandleFunc("/command", s.handleCommand)
+	s.mux.HandleFunc("/events", s.handleEvents)
+	return s
+}
+
+// ServeHTTP routes requests to the game endpoints
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleStatus returns the current game state
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "use GET for /status")
+		return
+	}
+	s.writeStatus(w)
+}
+
+// handleCommand advances the game by one turn and returns the new state
+func (s *Server) handleCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "use POST for /command")
+		return
+	}
+
+	var req CommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	if err := s.game.Step(req.Command); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrGameOver) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	s.writeStatus(w)
+}
+
+// handleEvents streams the game event feed as Server-Sent Events until the client disconnects
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "use GET for /events")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	events, unsubscribe := s.game.Events()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// Send a comment so the client knows the stream is live
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// writeStatus sends the game's StatusJSON as the response body
+func (s *Server) writeStatus(w http.ResponseWriter) {
+	body, err := s.game.StatusJSON()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// writeError sends a JSON error message with the given status code
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer starts an HTTP server around a quiet game
+func newTestServer(t *testing.T) (*Game, *httptest.Server) {
+	game := NewGame()
+	game.Out = io.Discard
+	server := httptest.NewServer(NewServer(game))
+	t.Cleanup(server.Close)
+	return game, server
+}
+
+// Test POST /command advances the turn and returns the new status
+func TestServerCommandAdvancesTurn(t *testing.T) {
+	game, server := newTestServer(t)
+
+	resp, err := http.Post(server.URL+"/command", "application/json", strings.NewReader(`{"command":"hit"}`))
+	if err != nil {
+		t.Fatalf("POST /command failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", resp.StatusCode)
+	}
+
+	var status GameStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Response was not valid status JSON: %v", err)
+	}
+	if status.Turns != 1 {
+		t.Errorf("Expected response to report turn 1, got %d", status.Turns)
+	}
+	if game.Turns != 1 {
+		t.Errorf("Expected game to advance to turn 1, got %d", game.Turns)
+	}
+}
+
+// Test GET /status reports the current state without advancing it
+func TestServerStatus(t *testing.T) {
+	game, server := newTestServer(t)
+
+	resp, err := http.Get(server.URL + "/status")
+	if err != nil {
+		t.Fatalf("GET /status failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var status GameStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Response was not valid status JSON: %v", err)
+	}
+	if status.Turns != 0 || status.AliveBees != DefaultTotalBees || status.PlayerHP != 100 {
+		t.Errorf("Unexpected initial status: %+v", status)
+	}
+	if game.Turns != 0 {
+		t.Errorf("GET /status should not advance the game, got turn %d", game.Turns)
+	}
+}
+
+// Test the server rejects bad commands and finished games
+func TestServerCommandErrors(t *testing.T) {
+	game, server := newTestServer(t)
+
+	resp, err := http.Post(server.URL+"/command", "application/json", strings.NewReader(`{"command":"dance"}`))
+	if err != nil {
+		t.Fatalf("POST /command failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown command, got %d", resp.StatusCode)
+	}
+
+	game.KillAllBees()
+	resp, err = http.Post(server.URL+"/command", "application/json", strings.NewReader(`{"command":"hit"}`))
+	if err != nil {
+		t.Fatalf("POST /command failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected 409 once the game is over, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(server.URL + "/command")
+	if err != nil {
+		t.Fatalf("GET /command failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET /command, got %d", resp.StatusCode)
+	}
+}
+
+// Test GET /events streams the event for an attack made through /command
+func TestServerEventStream(t *testing.T) {
+	game, server := newTestServer(t)
+	game.Config.PlayerMissChance = 0 // Guarantee the attack lands
+
+	resp, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected text/event-stream content type, got %q", ct)
+	}
+
+	cmdResp, err := http.Post(server.URL+"/command", "application/json", strings.NewReader(`{"command":"hit"}`))
+	if err != nil {
+		t.Fatalf("POST /command failed: %v", err)
+	}
+	cmdResp.Body.Close()
+
+	// Read frames until the player's attack shows up
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Stream ended before the attack event arrived: %v", err)
+		}
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		var event GameEvent
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			t.Fatalf("Event frame was not valid JSON: %v", err)
+		}
+		if event.Actor != "player" {
+			continue
+		}
+		if event.Action != EventPlayerHit && event.Action != EventBeeKilled {
+			t.Errorf("Expected a player hit event, got %q", event.Action)
+		}
+		if event.Turn != 1 {
+			t.Errorf("Expected event for turn 1, got %d", event.Turn)
+		}
+		break
+	}
+}
+
+// Test unsubscribing from the event feed closes the channel and stops delivery
+func TestEventsUnsubscribe(t *testing.T) {
+	game := NewGame()
+	events, unsubscribe := game.Events()
+
+	game.emit(GameEvent{Action: EventPlayerMiss, Actor: "player"})
+	if event := <-events; event.Action != EventPlayerMiss {
+		t.Errorf("Expected %q event, got %q", EventPlayerMiss, event.Action)
+	}
+
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("Expected the event channel to be closed after unsubscribing")
+	}
+
+	// Emitting after unsubscribe must not panic and unsubscribing twice is safe
+	game.emit(GameEvent{Action: EventPlayerMiss, Actor: "player"})
+	unsubscribe()
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// HeadlessMaxTurns caps how many rounds a game played without anyone watching may last, so a
+// config where neither side can ever land a hit still comes to an end. It is several times the
+// length of the longest default game.
+const HeadlessMaxTurns = 250
+
+// SimulatedGame is the outcome of one game played out by Simulate
+type SimulatedGame struct {
+	Seed       int64 // Seed used for both the player and bee randomness
+	Won        bool  // The player destroyed the hive and survived
+	Unfinished bool  // Neither side had won when HeadlessMaxTurns ran out
+	Turns      int
+	PlayerHP   int // Player HP when the game ended
+	BeesKilled int
+}
+
+// SimulationResult collects every game played out by Simulate
+type SimulationResult struct {
+	Games []SimulatedGame
+}
+
+// Simulate plays the given number of headless games with the config, attacking as config.AutoStrategy directs (or resting when exhausted).
+// Game i is seeded with config.PlayerSeed+i+1 so a simulation can be reproduced exactly.
+// A game still undecided after HeadlessMaxTurns rounds is stopped and counts as not won.
+func Simulate(config GameConfig, games int) SimulationResult {
+	baseSeed := config.PlayerSeed
+	config = headlessConfig(config)
+
+	result := SimulationResult{Games: make([]SimulatedGame, 0, games)}
+	for i := 0; i < games; i++ {
+		seed := baseSeed + int64(i) + 1
+		config.PlayerSeed = seed
+		config.BeeSeed = seed
+
+		game := NewGameWithConfig(config)
+		game.Out = io.Discard
+		game.playHeadless()
+
+		outcome := game.Result()
+		result.Games = append(result.Games, SimulatedGame{
+			Seed:       seed,
+			Won:        outcome.PlayerWon,
+			Unfinished: !outcome.Over,
+			Turns:      outcome.Turns,
+			PlayerHP:   outcome.PlayerHP,
+			BeesKilled: game.totalBees() - outcome.BeesRemaining,
+		})
+	}
+	return result
+}
+
+// headlessConfig strips the config of everything that waits on a person, for games nobody is
+// watching or playing along with: think delays, auto mode pauses, the input timeout, blocking
+// stings and a second player for the hive, plus the background damage monitor. Games played on
+// it should be driven by playHeadless, which caps them at HeadlessMaxTurns.
+func headlessConfig(config GameConfig) GameConfig {
+	config.DisableMonitor = true
+	config.DisableThinkDelay = true
+	config.AutoModeDelay = 0
+	config.InputTimeout = 0
+	config.BlockWindow = 0
+	config.TwoPlayer = false
+	return config
+}
+
+// playHeadless plays the auto strategy until the game is decided or HeadlessMaxTurns rounds have gone by
+func (g *Game) playHeadless() {
+	for round := 0; round < HeadlessMaxTurns && !g.IsGameOver(); round++ {
+		g.playRound(g.autoCommand())
+	}
+}
+
+// EstimateGames is how many games EstimateTurnsToWin simulates
+const EstimateGames = 500
+
+// EstimateTurnsToWin estimates how many turns the player needs to win with the config by simulating
+// EstimateGames games and averaging the turns of the ones they won. It returns +Inf if they never win.
+// Seeds come from config.PlayerSeed as in Simulate, so the estimate is repeatable.
+func EstimateTurnsToWin(config GameConfig) float64 {
+	turns, wins := 0, 0
+	for _, game := range Simulate(config, EstimateGames).Games {
+		if game.Won {
+			turns += game.Turns
+			wins++
+		}
+	}
+	if wins == 0 {
+		return math.Inf(1)
+	}
+	return float64(turns) / float64(wins)
+}
+
+// WinRate is the fraction of simulated games the player won
+func (r SimulationResult) WinRate() float64 {
+	if len(r.Games) == 0 {
+		return 0
+	}
+	wins := 0
+	for _, game := range r.Games {
+		if game.Won {
+			wins++
+		}
+	}
+	return float64(wins) / float64(len(r.Games))
+}
+
+// averages returns the mean turns, ending HP and bees killed across all games
+func (r SimulationResult) averages() (float64, float64, float64) {
+	if len(r.Games) == 0 {
+		return 0, 0, 0
+	}
+	var turns, playerHP, beesKilled int
+	for _, game := range r.Games {
+		turns += game.Turns
+		playerHP += game.PlayerHP
+		beesKilled += game.BeesKilled
+	}
+	n := float64(len(r.Games))
+	return float64(turns) / n, float64(playerHP) / n, float64(beesKilled) / n
+}
+
+// WriteCSV writes one row per simulated game followed by a summary row of win rate and averages
+func (r SimulationResult) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"seed", "won", "turns", "ending_hp", "bees_killed"}); err != nil {
+		return err
+	}
+
+	for _, game := range r.Games {
+		row := []string{
+			strconv.FormatInt(game.Seed, 10),
+			strconv.FormatBool(game.Won),
+			strconv.Itoa(game.Turns),
+			strconv.Itoa(game.PlayerHP),
+			strconv.Itoa(game.BeesKilled),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	// The summary row reports the win rate in the 'won' column and averages elsewhere
+	turns, playerHP, beesKilled := r.averages()
+	summary := []string{
+		"summary",
+		fmt.Sprintf("%.3f", r.WinRate()),
+		fmt.Sprintf("%.2f", turns),
+		fmt.Sprintf("%.2f", playerHP),
+		fmt.Sprintf("%.2f", beesKilled),
+	}
+	if err := cw.Write(summary); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Test a tiny simulation exports a header, one row per game and a summary row
+func TestSimulationWriteCSV(t *testing.T) {
+	config := DefaultConfig()
+	config.WorkerCount = 1
+	config.DroneCount = 2
+
+	result := Simulate(config, 3)
+	if len(result.Games) != 3 {
+		t.Fatalf("Expected 3 simulated games, got %d", len(result.Games))
+	}
+	for _, game := range result.Games {
+		if game.Turns == 0 {
+			t.Errorf("Expected seed %d to play at least one turn", game.Seed)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, got %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("Expected header, 3 game rows and a summary row, got %d rows", len(rows))
+	}
+
+	expectedHeader := []string{"seed", "won", "turns", "ending_hp", "bees_killed"}
+	if !reflect.DeepEqual(rows[0], expectedHeader) {
+		t.Errorf("Expected header %v, got %v", expectedHeader, rows[0])
+	}
+	if rows[1][0] != "1" || rows[3][0] != "3" {
+		t.Errorf("Expected rows for seeds 1-3, got %v", rows[1:4])
+	}
+	if rows[4][0] != "summary" {
+		t.Errorf("Expected a trailing summary row, got %v", rows[4])
+	}
+
+	// The same config reproduces the same games
+	if again := Simulate(config, 3); !reflect.DeepEqual(again, result) {
+		t.Error("Expected a simulation to be reproducible from its seeds")
+	}
+}
+
+// Test the estimate for a lone Drone matches the analytic expectation of hits needed over hit chance
+func TestEstimateTurnsToWinSingleDrone(t *testing.T) {
+	config := DefaultConfig()
+	config.QueenCount = 0
+	config.WorkerCount = 0
+	config.DroneCount = 1
+
+	// Each landed hit deals DroneTakesDamage, and each turn lands with probability 1 - miss chance
+	hits := float64(DroneHP / DroneTakesDamage)
+	want := hits / (1 - config.PlayerMissChance)
+
+	got := EstimateTurnsToWin(config)
+	if math.Abs(got-want) > 0.15 {
+		t.Errorf("Expected about %.3f turns to win, estimated %.3f", want, got)
+	}
+
+	config.PlayerMissChance = 1
+	if got := EstimateTurnsToWin(config); !math.IsInf(got, 1) {
+		t.Errorf("Expected +Inf when the player can never hit, got %v", got)
+	}
+}
+
+// Test a game where neither side can ever hit stops at the turn cap instead of running forever
+func TestSimulateStopsAtTurnCap(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 1
+	config.BeesMissChance = 1
+
+	result := Simulate(config, 2)
+	for _, game := range result.Games {
+		if game.Won || !game.Unfinished {
+			t.Errorf("Expected seed %d to be recorded as unfinished, got %+v", game.Seed, game)
+		}
+		if game.Turns > HeadlessMaxTurns {
+			t.Errorf("Expected at most %d turns, seed %d played %d", HeadlessMaxTurns, game.Seed, game.Turns)
+		}
+	}
+	if result.WinRate() != 0 {
+		t.Errorf("Expected unfinished games not to count as wins, win rate %.2f", result.WinRate())
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"fmt"
+	"io"
+)
+
+// Kinds of key moment a spectator recap picks out
+const (
+	MomentFirstBlood = "first_blood" // The first bee the player killed
+	MomentQueenDeath = "queen_death" // A Queen was killed
+	MomentFinalBlow  = "final_blow"  // The action that decided the game
+)
+
+// Moment is one highlight of a finished game
+type Moment struct {
+	Turn        int    `json:"turn"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+// Spectate plays a whole game hands-free, the auto strategy against the bee AI, without any
+// per-turn narration, then writes a recap of its key moments to w. A config without seeds
+// is seeded from its Fingerprint, so the same config always replays the same game.
+// A game still undecided after HeadlessMaxTurns rounds is stopped and recapped as unfinished.
+func Spectate(config GameConfig, w io.Writer) GameResult {
+	config = headlessConfig(config)
+	config.SeedFromConfig = true
+
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+	game.AutoMode = true
+	game.playHeadless()
+
+	result := game.Result()
+	fmt.Fprintln(w, "=== Spectator Recap ===")
+	for _, moment := range KeyMoments(game.Transcript()) {
+		fmt.Fprintf(w, "Turn %d: %s\n", moment.Turn, moment.Description)
+	}
+	switch {
+	case !result.Over:
+		fmt.Fprintf(w, "Neither side had won after %d turns, so the game was called off.\n", result.Turns)
+	case result.MutualDefeat:
+		fmt.Fprintf(w, "The player and the last of the hive fell together after %d turns.\n", result.Turns)
+	case result.PlayerWon:
+		fmt.Fprintf(w, "The player won in %d turns with %d HP to spare.\n", result.Turns, result.PlayerHP)
+	default:
+		fmt.Fprintf(w, "The hive won after %d turns with %d bees still standing.\n", result.Turns, result.BeesRemaining)
+	}
+	return result
+}
+
+// KeyMoments picks the highlights out of a game's recorded events, in the order they happened:
+// the first kill, every Queen death and, once the game is decided, the final blow
+func KeyMoments(records []TurnRecord) []Moment {
+	var moments []Moment
+	firstBlood := false
+	for _, record := range records {
+		if record.Action != EventBeeKilled {
+			continue
+		}
+		if !firstBlood {
+			firstBlood = true
+			moments = append(moments, Moment{Turn: record.Turn, Kind: MomentFirstBlood,
+				Description: fmt.Sprintf("First blood! The player killed a %s bee (#%d).", record.Target, record.BeeID)})
+		}
+		if record.Target == Queen.String() {
+			moments = append(moments, Moment{Turn: record.Turn, Kind: MomentQueenDeath,
+				Description: fmt.Sprintf("The Queen (#%d) is dead!", record.BeeID)})
+		}
+	}
+
+	if final, ok := finalBlow(records); ok {
+		moments = append(moments, final)
+	}
+	return moments
+}
+
+// finalBlow finds the record that decided the game: the player's death, or the blow that
+// emptied the hive for good. Events after it, like a dying Worker's barbed sting, don't count,
+// but a boss rush round starting afterwards means the game went on.
+func finalBlow(records []TurnRecord) (Moment, bool) {
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		moment := Moment{Turn: record.Turn, Kind: MomentFinalBlow}
+		switch {
+		case record.Action == EventBossRound:
+			return Moment{}, false
+		case record.Action == EventPlayerDied:
+			moment.Description = fmt.Sprintf("Final blow: the %s stung the player to death.", record.Actor)
+		case record.Action == EventQueenWipe && record.BeesLeft == 0:
+			moment.Description = "Final blow: the Queen's death brought the whole hive down."
+		case record.Action == EventBeeKilled && record.BeesLeft == 0:
+			moment.Description = fmt.Sprintf("Final blow: the player killed the last bee, a %s (#%d).", record.Target, record.BeeID)
+		default:
+			continue
+		}
+		return moment, true
+	}
+	return Moment{}, false
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test a game where neither side can ever hit is called off at the turn cap instead of running forever
+func TestSpectateStopsAtTurnCap(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 1
+	config.BeesMissChance = 1
+
+	var out bytes.Buffer
+	result := Spectate(config, &out)
+	if result.Over || result.Turns > HeadlessMaxTurns {
+		t.Fatalf("Expected an unfinished game of at most %d turns, got %+v", HeadlessMaxTurns, result)
+	}
+	if !strings.Contains(out.String(), "called off") {
+		t.Errorf("Expected the recap to say the game was called off, got:\n%s", out.String())
+	}
+}
+
+// Test spectating a seeded game recaps the Queen's death and the blow that ended it
+func TestSpectateRecap(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	config.BeesMissChance = 1
+	config.AutoStrategy = AutoStrategyFocusQueen
+	config.PlayerSeed = 3
+	config.BeeSeed = 3
+
+	var out bytes.Buffer
+	result := Spectate(config, &out)
+
+	if !result.Over || !result.PlayerWon {
+		t.Fatalf("Expected the player to win a game the bees can't score in, got %+v", result)
+	}
+	recap := out.String()
+	for _, want := range []string{"Spectator Recap", "First blood!", "The Queen (#1) is dead!", "Final blow: the Queen's death brought the whole hive down."} {
+		if !strings.Contains(recap, want) {
+			t.Errorf("Expected the recap to mention %q, got:\n%s", want, recap)
+		}
+	}
+	if strings.Contains(recap, "--- Turn") {
+		t.Errorf("Expected no per-turn narration in the recap, got:\n%s", recap)
+	}
+
+	// The same seeded config replays the same recap
+	var again bytes.Buffer
+	Spectate(config, &again)
+	if again.String() != recap {
+		t.Errorf("Expected an identical recap on replay, got:\n%s\nthen:\n%s", recap, again.String())
+	}
+}
+
+// Test a defeat's final blow names the bee that landed it
+func TestKeyMomentsPlayerDeath(t *testing.T) {
+	records := []TurnRecord{
+		{Turn: 1, Action: EventBeeKilled, Actor: "player", Target: "Drone", BeeID: 9, BeesLeft: 30},
+		{Turn: 4, Action: EventBeeSting, Actor: "Worker", Target: "player", BeesLeft: 30},
+		{Turn: 4, Action: EventPlayerDied, Actor: "Worker", Target: "player", BeesLeft: 30},
+		{Turn: 4, Action: EventBarbedSting, Actor: "Worker", Target: "player", BeesLeft: 29},
+	}
+
+	moments := KeyMoments(records)
+	if len(moments) != 2 {
+		t.Fatalf("Expected first blood and the final blow, got %+v", moments)
+	}
+	if moments[0].Kind != MomentFirstBlood || moments[0].Turn != 1 {
+		t.Errorf("Expected first blood on turn 1, got %+v", moments[0])
+	}
+	if moments[1].Kind != MomentFinalBlow || moments[1].Description != "Final blow: the Worker stung the player to death." {
+		t.Errorf("Expected the Worker's final blow, got %+v", moments[1])
+	}
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Outcomes reported by GameResult.Outcome and the JSON summary
+const (
+	OutcomeWin        = "win"
+	OutcomeLoss       = "loss"
+	OutcomeDraw       = "draw"
+	OutcomeUnfinished = "unfinished"
+)
+
+// Outcome names how the game stands: "win", "loss", "draw" or "unfinished"
+func (r GameResult) Outcome() string {
+	switch {
+	case !r.Over:
+		return OutcomeUnfinished
+	case r.MutualDefeat:
+		return OutcomeDraw
+	case r.PlayerWon:
+		return OutcomeWin
+	default:
+		return OutcomeLoss
+	}
+}
+
+// EndSummary is the machine-readable end-of-game report printed by EndGame with JSONSummary
+type EndSummary struct {
+	Outcome       string          `json:"outcome"`
+	Turns         int             `json:"turns"`
+	PlayerHP      int             `json:"player_hp"`
+	PlayerMaxHP   int             `json:"player_max_hp"`
+	BeesRemaining map[BeeType]int `json:"bees_remaining"`
+	BeesKilled    map[BeeType]int `json:"bees_killed"`
+	Score         int             `json:"score"`
+}
+
+// Summary builds the end-of-game report, counting every bee type even when none are left
+func (g *Game) Summary() EndSummary {
+	summary := EndSummary{
+		Outcome:       g.Result().Outcome(),
+		BeesRemaining: make(map[BeeType]int, 3),
+		BeesKilled:    make(map[BeeType]int, 3),
+		Score:         g.Score(),
+	}
+
+	g.mu.RLock()
+	summary.Turns = g.Turns
+	summary.PlayerHP = g.Player.HP
+	summary.PlayerMaxHP = g.Player.MaxHP
+	for _, beeType := range []BeeType{Queen, Worker, Drone} {
+		summary.BeesRemaining[beeType] = 0
+		summary.BeesKilled[beeType] = 0
+		for _, bee := range g.Hive[beeType] {
+			if bee.IsAlive() {
+				summary.BeesRemaining[beeType]++
+			} else {
+				summary.BeesKilled[beeType]++
+			}
+		}
+	}
+	g.mu.RUnlock()
+	return summary
+}
+
+// printJSONSummary writes the end-of-game report as a single line of JSON to SummaryOut
+func (g *Game) printJSONSummary() {
+	w := g.SummaryOut
+	if w == nil {
+		w = g.out()
+	}
+	if err := json.NewEncoder(w).Encode(g.Summary()); err != nil {
+		fmt.Fprintf(g.out(), "⚠️ Couldn't encode the summary: %v\n", err)
+	}
+}
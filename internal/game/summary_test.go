@@ -0,0 +1,83 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Test JSONSummary makes EndGame print one parseable JSON object for a won game
+func TestEndGameJSONSummary(t *testing.T) {
+	config := DefaultConfig()
+	config.JSONSummary = true
+	config.PlayerMissChance = 0
+	config.WorkerCount = 2
+	config.DroneCount = 3
+	game := NewGameWithConfig(config)
+	var buf bytes.Buffer
+	game.Out = &buf
+
+	primeQueenKill(game)
+	game.PlayerTurn("hit")
+	if !game.Result().PlayerWon {
+		t.Fatalf("Expected the Queen kill to win the game, got %+v", game.Result())
+	}
+
+	buf.Reset()
+	game.EndGame()
+	if lines := strings.Count(strings.TrimSpace(buf.String()), "\n"); lines != 0 {
+		t.Errorf("Expected a single line of JSON, got: %s", buf.String())
+	}
+
+	var summary EndSummary
+	if err := json.Unmarshal(buf.Bytes(), &summary); err != nil {
+		t.Fatalf("Expected the summary to parse as JSON: %v\n%s", err, buf.String())
+	}
+	if summary.Outcome != OutcomeWin || summary.Turns != 1 || summary.PlayerHP != config.PlayerHP || summary.Score != game.Score() {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	wantKilled := map[BeeType]int{Queen: 1, Worker: 2, Drone: 3}
+	for beeType, want := range wantKilled {
+		if summary.BeesKilled[beeType] != want || summary.BeesRemaining[beeType] != 0 {
+			t.Errorf("Expected %d %ss killed and none remaining, got %d killed and %d remaining",
+				want, beeType, summary.BeesKilled[beeType], summary.BeesRemaining[beeType])
+		}
+	}
+
+	// The raw JSON keys bee types by name
+	if !strings.Contains(buf.String(), `"bees_killed":{"Drone":3,"Queen":1,"Worker":2}`) {
+		t.Errorf("Expected bee counts keyed by type name, got: %s", buf.String())
+	}
+}
+
+// Test SummaryOut keeps the JSON summary apart from the narration of a whole game
+func TestJSONSummaryOut(t *testing.T) {
+	config := DefaultConfig()
+	config.JSONSummary = true
+	config.PlayerMissChance = 0
+	config.WorkerCount = 0
+	config.DroneCount = 0
+	config.DisableThinkDelay = true
+	config.DisableMonitor = true
+	game := NewGameWithConfig(config)
+	var narration, report bytes.Buffer
+	game.Out = &narration
+	game.SummaryOut = &report
+	game.In = strings.NewReader("hit\n")
+	game.GetBeesByType(Queen)[0].HP = 1 // One hit wins
+
+	game.Start()
+	game.PlayGame()
+
+	var summary EndSummary
+	if err := json.Unmarshal(report.Bytes(), &summary); err != nil {
+		t.Fatalf("Expected SummaryOut to hold nothing but the JSON summary: %v\n%s", err, report.String())
+	}
+	if summary.Turns != 1 {
+		t.Errorf("Expected a summary of 1 turn, got %+v", summary)
+	}
+	if !strings.Contains(narration.String(), "Turn 1") || strings.Contains(narration.String(), `"outcome"`) {
+		t.Errorf("Expected the narration on Out without the summary, got: %s", narration.String())
+	}
+}
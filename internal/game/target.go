@@ -0,0 +1,59 @@
+package game
+
+// Randomizer is the randomness a TargetSelector may draw on (*rand.Rand satisfies it)
+type Randomizer interface {
+	Intn(n int) int
+	Float64() float64
+}
+
+// TargetSelector picks which living bee a landed 'hit' strikes.
+// Embedders can set Game.TargetSelector to plug in their own strategy.
+type TargetSelector interface {
+	Select(bees []*Bee, rng Randomizer) *Bee
+}
+
+// UniformSelector picks any living bee with equal chance
+type UniformSelector struct{}
+
+// Select picks a bee uniformly at random
+func (UniformSelector) Select(bees []*Bee, rng Randomizer) *Bee {
+	return bees[rng.Intn(len(bees))]
+}
+
+// WeightedSelector picks bees with a relative chance per type, falling back to uniform
+// when no weights are set or every living bee's weight is zero
+type WeightedSelector struct {
+	Weights map[BeeType]float64
+}
+
+// Select walks the cumulative weights until it passes the rolled value
+func (s WeightedSelector) Select(bees []*Bee, rng Randomizer) *Bee {
+	if len(s.Weights) == 0 {
+		return UniformSelector{}.Select(bees, rng)
+	}
+
+	totalWeight := 0.0
+	for _, bee := range bees {
+		totalWeight += s.Weights[bee.Type]
+	}
+	if totalWeight <= 0 {
+		return UniformSelector{}.Select(bees, rng)
+	}
+
+	roll := rng.Float64() * totalWeight
+	for _, bee := range bees {
+		roll -= s.Weights[bee.Type]
+		if roll < 0 {
+			return bee
+		}
+	}
+	return bees[len(bees)-1]
+}
+
+// WeakestSelector always picks the living bee with the least HP, breaking ties by lowest ID
+type WeakestSelector struct{}
+
+// Select picks the weakest bee without using any randomness
+func (WeakestSelector) Select(bees []*Bee, _ Randomizer) *Bee {
+	return weakestBee(bees)
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// TurnRecord is one row of a game transcript, captured from each game event
+type TurnRecord struct {
+	Turn     int    `json:"turn"`
+	Action   string `json:"action"`
+	Actor    string `json:"actor"`
+	Target   string `json:"target,omitempty"`
+	BeeID    int    `json:"bee_id,omitempty"`
+	Damage   int    `json:"damage,omitempty"`
+	PlayerHP int    `json:"player_hp"`
+	BeesLeft int    `json:"bees_left"`
+}
+
+// Transcript returns a copy of every action recorded so far this game
+func (g *Game) Transcript() []TurnRecord {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+
+	transcript := make([]TurnRecord, len(g.transcript))
+	copy(transcript, g.transcript)
+	return transcript
+}
+
+// turnRecords returns a copy of the actions recorded on one turn, found through the turn index
+func (g *Game) turnRecords(turn int) []TurnRecord {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+
+	records := make([]TurnRecord, 0, len(g.turnIndex[turn]))
+	for _, i := range g.turnIndex[turn] {
+		records = append(records, g.transcript[i])
+	}
+	return records
+}
+
+// TurnSummary condenses everything recorded on a turn into one line, e.g.
+// "T5: you hit Worker#3 (-25), Drone#9 stung you (-1), HP 80/100, bees 18"
+func (g *Game) TurnSummary(turn int) string {
+	var parts []string
+	for _, record := range g.turnRecords(turn) {
+		bee := fmt.Sprintf("%s#%d", record.Target, record.BeeID)
+		switch record.Action {
+		case EventPlayerMiss:
+			parts = append(parts, "you missed")
+		case EventPlayerHit:
+			parts = append(parts, fmt.Sprintf("you hit %s (-%d)", bee, record.Damage))
+		case EventBeeKilled:
+			parts = append(parts, fmt.Sprintf("you killed %s (-%d)", bee, record.Damage))
+		case EventQueenWipe:
+			parts = append(parts, "the hive collapsed")
+		case EventBeeSting:
+			parts = append(parts, fmt.Sprintf("%s#%d stung you (-%d)", record.Actor, record.BeeID, record.Damage))
+		case EventBeeMiss:
+			parts = append(parts, "the bees missed")
+		case EventSwarmPressure:
+			parts = append(parts, fmt.Sprintf("swarm pressure (-%d)", record.Damage))
+		case EventReinforcement:
+			parts = append(parts, fmt.Sprintf("%s joined", bee))
+		case EventBarbedSting:
+			parts = append(parts, fmt.Sprintf("%s#%d died stinging", record.Actor, record.BeeID))
+		case EventEnrage:
+			parts = append(parts, "the hive enraged")
+		case EventPlayerRevived:
+			parts = append(parts, "you clung to life")
+		case EventPlayerRespawned:
+			parts = append(parts, "you respawned")
+		case EventPlayerDied:
+			parts = append(parts, "you died")
+		case EventStingBlocked:
+			parts = append(parts, fmt.Sprintf("you blocked %s", bee))
+		case EventBeeHealed:
+			parts = append(parts, fmt.Sprintf("%s was healed", bee))
+		case EventBeeFled:
+			parts = append(parts, fmt.Sprintf("%s fled", bee))
+		case EventBeeReturned:
+			parts = append(parts, fmt.Sprintf("%s returned", bee))
+		case EventBossRound:
+			parts = append(parts, "a boss round began")
+		}
+	}
+
+	status := g.Status()
+	parts = append(parts, fmt.Sprintf("HP %d/%d", status.PlayerHP, status.PlayerMaxHP), fmt.Sprintf("bees %d", status.AliveBees))
+	return fmt.Sprintf("T%d: %s", turn, strings.Join(parts, ", "))
+}
+
+// RenderTranscript prints a transcript as an aligned table for post-game analysis
+func RenderTranscript(w io.Writer, transcript []TurnRecord) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Turn\tAction\tTarget\tDmg\tPlayerHP\tBeesLeft")
+	for _, record := range transcript {
+		target := record.Target
+		if target == "" {
+			target = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%d\n",
+			record.Turn, record.Action, target, record.Damage, record.PlayerHP, record.BeesLeft)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test RenderTranscript prints an aligned header and one row per record
+func TestRenderTranscript(t *testing.T) {
+	transcript := []TurnRecord{
+		{Turn: 1, Action: EventPlayerHit, Actor: "player", Target: "Drone", Damage: 30, PlayerHP: 100, BeesLeft: 31},
+		{Turn: 1, Action: EventBeeSting, Actor: "Worker", Target: "player", Damage: 5, PlayerHP: 95, BeesLeft: 31},
+		{Turn: 2, Action: EventPlayerMiss, Actor: "player", PlayerHP: 95, BeesLeft: 31},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTranscript(&buf, transcript); err != nil {
+		t.Fatalf("RenderTranscript failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header plus 3 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "Turn Action Target Dmg PlayerHP BeesLeft" {
+		t.Errorf("Unexpected header: %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "1 player_hit Drone 30 100 31" {
+		t.Errorf("Unexpected first row: %q", lines[1])
+	}
+	if fields := strings.Fields(lines[3]); strings.Join(fields, " ") != "2 player_miss - 0 95 31" {
+		t.Errorf("Unexpected miss row: %q", lines[3])
+	}
+
+	// Columns are aligned: every row starts its Action column at the same offset
+	actionColumn := strings.Index(lines[0], "Action")
+	for _, line := range lines[1:] {
+		if line[actionColumn-1] != ' ' || line[actionColumn] == ' ' {
+			t.Errorf("Row is not aligned with the Action column: %q", line)
+		}
+	}
+}
+
+// Test the game records a transcript entry for each action
+func TestGameTranscriptRecordsActions(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerMissChance = 0
+	game := NewGameWithConfig(config)
+	game.Out = io.Discard
+
+	if err := game.Step("hit"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	transcript := game.Transcript()
+	if len(transcript) != 2 {
+		t.Fatalf("Expected a player action and a bee action, got %+v", transcript)
+	}
+	if transcript[0].Actor != "player" || transcript[0].Turn != 1 || transcript[0].Damage == 0 {
+		t.Errorf("Unexpected player record: %+v", transcript[0])
+	}
+	if transcript[1].Actor == "player" || transcript[1].BeesLeft != DefaultTotalBees-countKills(transcript) {
+		t.Errorf("Unexpected bee record: %+v", transcript[1])
+	}
+
+	// Restarting starts a fresh transcript
+	game.Reset()
+	if len(game.Transcript()) != 0 {
+		t.Error("Expected transcript to be cleared on reset")
+	}
+}
+
+// Test each turn's summary holds only that turn's records, deaths and respawns included
+func TestTurnSummaryCoversDeaths(t *testing.T) {
+	config := DefaultConfig()
+	config.PlayerHP = QueenDamage
+	config.Lives = 2
+	config.PlayerMissChance = 1
+	config.DisableThinkDelay = true
+	game := newSingleBeeGame(config, Queen)
+	game.Out = io.Discard
+
+	for !game.IsGameOver() {
+		if err := game.Step("hit"); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+
+	if got, want := game.TurnSummary(1), "T1: you missed, Queen#1 stung you (-10), you respawned, "; !strings.HasPrefix(got, want) {
+		t.Errorf("Expected turn 1 to summarize as %q..., got %q", want, got)
+	}
+	if got, want := game.TurnSummary(2), "T2: you missed, Queen#1 stung you (-10), you died, "; !strings.HasPrefix(got, want) {
+		t.Errorf("Expected turn 2 to summarize as %q..., got %q", want, got)
+	}
+}
+
+// countKills counts the bee kills in a transcript
+func countKills(transcript []TurnRecord) int {
+	kills := 0
+	for _, record := range transcript {
+		if record.Action == EventBeeKilled {
+			kills++
+		}
+	}
+	return kills
+}
+
+// Test two deterministic games with the same seed replay identically, down to the narration
+func TestNewDeterministicGameReplays(t *testing.T) {
+	config := DefaultConfig()
+	config.NameHive = true
+	config.ReinforcementChance = 0.3
+
+	play := func(seed int64) ([]TurnRecord, string) {
+		game := NewDeterministicGame(config, seed)
+		var out bytes.Buffer
+		game.Out = &out
+		for turn := 0; turn < 40 && !game.IsGameOver(); turn++ {
+			if err := game.Step("hit"); err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+		}
+		return game.Transcript(), out.String()
+	}
+
+	first, firstOut := play(42)
+	second, secondOut := play(42)
+	if len(first) == 0 {
+		t.Fatal("Expected the games to record a transcript")
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same seed to produce identical transcripts")
+	}
+	if firstOut != secondOut {
+		t.Error("Expected the same seed to produce identical narration")
+	}
+
+	if other, _ := play(43); reflect.DeepEqual(first, other) {
+		t.Error("Expected a different seed to play out differently")
+	}
+}